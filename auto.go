@@ -0,0 +1,232 @@
+package fastdiv
+
+import (
+	"fmt"
+	"math/bits"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Strategy identifies an algorithm for the division by a divisor known only at runtime.
+type Strategy uint8
+
+// The strategies available to AutoUint64.
+const (
+	StrategyShift    Strategy = iota // shift and mask, for powers of two
+	StrategyLemire                   // direct remainder of Uint64
+	StrategyGMW                      // magic number multiplier of Uint64GMW
+	StrategyHardware                 // division instruction
+)
+
+var strategyNames = [...]string{
+	StrategyShift:    "StrategyShift",
+	StrategyLemire:   "StrategyLemire",
+	StrategyGMW:      "StrategyGMW",
+	StrategyHardware: "StrategyHardware",
+}
+
+func (s Strategy) String() string {
+	if int(s) < len(strategyNames) {
+		return strategyNames[s]
+	}
+	return fmt.Sprintf("Strategy(%d)", uint8(s))
+}
+
+// NoCalibrateEnv is the environment variable that disables the calibration at package init
+// when set to a non-empty value. AutoUint64 then uses StrategyLemire for every divisor
+// that is not a power of two.
+const NoCalibrateEnv = "FASTDIV_NOCALIBRATE"
+
+// autoStrategy is the strategy of AutoUint64 for divisors that are not powers of two,
+// indexed by whether their GMW multiplier needs the add indicator.
+var autoStrategy [2]atomic.Uint32
+
+func init() {
+	strategies := [2]Strategy{StrategyLemire, StrategyLemire}
+	if os.Getenv(NoCalibrateEnv) == "" {
+		strategies = calibrate()
+	}
+	for i, s := range strategies {
+		autoStrategy[i].Store(uint32(s))
+	}
+}
+
+// SetAutoStrategy forces the strategy of every AutoUint64 created afterwards for a divisor
+// that is not a power of two, in place of the calibrated one. Powers of two keep StrategyShift.
+// It is safe to call concurrently with NewAutoUint64.
+// If s is StrategyShift or unknown, SetAutoStrategy panics.
+func SetAutoStrategy(s Strategy) {
+	if s == StrategyShift || s > StrategyHardware {
+		panic("fastdiv: SetAutoStrategy(" + s.String() + ")")
+	}
+	for i := range autoStrategy {
+		autoStrategy[i].Store(uint32(s))
+	}
+}
+
+// AutoUint64 calculates division by using the strategy that is fastest on the
+// current CPU for its divisor. Powers of two always use a shift and a mask.
+// The other divisors use the strategy selected for their class by a one-time
+// calibration at package init, so the choice is made once per process:
+// divisors whose GMW multiplier fits in 64 bits and those that need the add
+// indicator of a 65-bit multiplier are timed separately.
+type AutoUint64 struct {
+	d      uint64
+	s      Strategy
+	sh     uint8
+	lemire Uint64
+	gmw    Uint64GMW
+}
+
+// NewAutoUint64 initializes a new divisor for d != 0 with the fastest strategy.
+// If d == 0, a runtime divide-by-zero panic is raised.
+func NewAutoUint64(d uint64) AutoUint64 {
+	if d != 0 && d&(d-1) == 0 {
+		return newAutoUint64(d, StrategyShift)
+	}
+	gmw := NewUint64GMW(d)
+	s := Strategy(autoStrategy[gmw.add&1].Load())
+	if s == StrategyGMW {
+		return AutoUint64{d: d, s: s, gmw: gmw}
+	}
+	return newAutoUint64(d, s)
+}
+
+// TryNewAutoUint64 initializes a new divisor like NewAutoUint64,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewAutoUint64(d uint64) (AutoUint64, error) {
+	if d == 0 {
+		return AutoUint64{}, ErrZeroDivisor
+	}
+	return NewAutoUint64(d), nil
+}
+
+// newAutoUint64 initializes a divisor with the strategy s,
+// StrategyShift requires d to be a power of two.
+func newAutoUint64(d uint64, s Strategy) AutoUint64 {
+	a := AutoUint64{d: d, s: s}
+	switch s {
+	case StrategyShift:
+		a.sh = uint8(bits.TrailingZeros64(d))
+		// the slice methods use the shifts and masks of Uint64 for powers of two
+		a.lemire = NewUint64(d)
+	case StrategyGMW:
+		a.gmw = NewUint64GMW(d)
+	default:
+		// the hardware strategy uses the direct remainder for Divisible
+		a.lemire = NewUint64(d)
+	}
+	return a
+}
+
+// Strategy returns the strategy used for d.
+func (d AutoUint64) Strategy() Strategy {
+	return d.s
+}
+
+// Div calculates n / d.
+func (d AutoUint64) Div(n uint64) uint64 {
+	switch d.s {
+	case StrategyShift:
+		return n >> (d.sh & 63)
+	case StrategyLemire:
+		return d.lemire.Div(n)
+	case StrategyGMW:
+		return d.gmw.Div(n)
+	default:
+		return n / d.d
+	}
+}
+
+// Mod calculates n % d.
+func (d AutoUint64) Mod(n uint64) uint64 {
+	switch d.s {
+	case StrategyShift:
+		return n & (d.d - 1)
+	case StrategyLemire:
+		return d.lemire.Mod(n)
+	case StrategyGMW:
+		return d.gmw.Mod(n)
+	default:
+		return n % d.d
+	}
+}
+
+// DivMod calculates n / d and n % d.
+func (d AutoUint64) DivMod(n uint64) (q, r uint64) {
+	switch d.s {
+	case StrategyShift:
+		return n >> (d.sh & 63), n & (d.d - 1)
+	case StrategyLemire:
+		return d.lemire.DivMod(n)
+	case StrategyGMW:
+		return d.gmw.DivMod(n)
+	default:
+		return n / d.d, n % d.d
+	}
+}
+
+// Divisible determines whether n is exactly divisible by d.
+func (d AutoUint64) Divisible(n uint64) bool {
+	switch d.s {
+	case StrategyShift:
+		return n&(d.d-1) == 0
+	case StrategyGMW:
+		return d.gmw.Divisible(n)
+	default:
+		return d.lemire.Divisible(n)
+	}
+}
+
+// calibrate returns the strategies with the lowest time for a mix of Div and Mod
+// by a divisor that is not a power of two, first for a GMW multiplier that fits
+// in 64 bits and then for one that needs the add indicator. It takes about 0.1ms.
+func calibrate() [2]Strategy {
+	var src [512]uint64
+	x := uint64(0x9e3779b97f4a7c15)
+	for i := range src {
+		// xorshift, so the quotients are not predictable
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+		src[i] = x
+	}
+	// both divisors are prime and about 2^30, so only the multiplier differs
+	return [2]Strategy{calibrateDivisor(1000000007, &src), calibrateDivisor(999999937, &src)}
+}
+
+// calibrateDivisor returns the strategy with the lowest time for the divisor.
+// The strategies take turns so that frequency scaling and cache warm-up affect
+// them alike, and the fastest of the rounds counts. Another strategy replaces
+// StrategyLemire only if it is faster by more than an eighth, so timing noise
+// does not flip the choice between strategies that are about as fast.
+func calibrateDivisor(divisor uint64, src *[512]uint64) Strategy {
+	const rounds = 4
+	strategies := [...]Strategy{StrategyLemire, StrategyGMW, StrategyHardware}
+	var elapsed [len(strategies)]time.Duration
+	for r := 0; r < rounds; r++ {
+		for i, s := range strategies {
+			a := newAutoUint64(divisor, s)
+			start := time.Now()
+			var sum uint64
+			for _, n := range src {
+				sum += a.Div(n) + a.Mod(n)
+			}
+			if t := time.Since(start); r == 0 || t < elapsed[i] {
+				elapsed[i] = t
+			}
+			calibrateSink += sum
+		}
+	}
+	best := 0
+	for i := range strategies {
+		if elapsed[i] < elapsed[best]-elapsed[0]/8 {
+			best = i
+		}
+	}
+	return strategies[best]
+}
+
+// calibrateSink keeps the calibration loops from being optimized away.
+var calibrateSink uint64
@@ -0,0 +1,118 @@
+package fastdiv
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+var autoStrategies = []Strategy{StrategyLemire, StrategyGMW, StrategyHardware}
+
+func TestAutoUint64(t *testing.T) {
+	checkAutoUint64 := func(x, y uint64) bool {
+		if y == 0 {
+			return true
+		}
+		ds := []AutoUint64{NewAutoUint64(y)}
+		for _, s := range autoStrategies {
+			ds = append(ds, newAutoUint64(y, s))
+		}
+		for _, d := range ds {
+			q, r := d.DivMod(x)
+			if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(checkAutoUint64, nil); err != nil {
+		t.Error(err)
+	}
+
+	edges := []uint64{0, 1, 2, 3, 7, 1 << 32, math.MaxUint64 / 2, math.MaxUint64/2 + 1, math.MaxUint64 - 1, math.MaxUint64}
+	for _, y := range edges[1:] {
+		for _, x := range edges {
+			if !checkAutoUint64(x, y) {
+				t.Errorf("%d / %d: mismatch", x, y)
+			}
+		}
+	}
+}
+
+func TestAutoUint64Strategy(t *testing.T) {
+	for s := 0; s < 64; s++ {
+		if d := NewAutoUint64(1 << s); d.Strategy() != StrategyShift {
+			t.Errorf("NewAutoUint64(1<<%d).Strategy() = %v, want %v", s, d.Strategy(), StrategyShift)
+		}
+	}
+	// 7 needs the add indicator of a 65-bit GMW multiplier, 3 does not
+	calibrated := [2]Strategy{Strategy(autoStrategy[0].Load()), Strategy(autoStrategy[1].Load())}
+	if d := NewAutoUint64(7); d.Strategy() != calibrated[1] || d.Strategy() == StrategyShift {
+		t.Errorf("NewAutoUint64(7).Strategy() = %v, want %v", d.Strategy(), calibrated[1])
+	}
+	if d := NewAutoUint64(3); d.Strategy() != calibrated[0] || d.Strategy() == StrategyShift {
+		t.Errorf("NewAutoUint64(3).Strategy() = %v, want %v", d.Strategy(), calibrated[0])
+	}
+	for _, s := range calibrate() {
+		if s == StrategyShift || s > StrategyHardware {
+			t.Errorf("calibrate() = %v", s)
+		}
+	}
+	t.Logf("calibrated strategies: %v", calibrated)
+
+	if _, err := TryNewAutoUint64(0); err != ErrZeroDivisor {
+		t.Errorf("TryNewAutoUint64(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if got := Strategy(9).String(); got != "Strategy(9)" {
+		t.Errorf("Strategy(9).String() = %q", got)
+	}
+}
+
+func TestSetAutoStrategy(t *testing.T) {
+	defer func(saved [2]uint32) {
+		for i, s := range saved {
+			autoStrategy[i].Store(s)
+		}
+	}([2]uint32{autoStrategy[0].Load(), autoStrategy[1].Load()})
+	for _, s := range autoStrategies {
+		SetAutoStrategy(s)
+		for _, y := range []uint64{3, 7, 1000000007, 999999937} {
+			if d := NewAutoUint64(y); d.Strategy() != s || d.Div(100) != 100/y {
+				t.Errorf("SetAutoStrategy(%v): NewAutoUint64(%d).Strategy() = %v", s, y, d.Strategy())
+			}
+		}
+		if d := NewAutoUint64(8); d.Strategy() != StrategyShift {
+			t.Errorf("SetAutoStrategy(%v): NewAutoUint64(8).Strategy() = %v, want %v", s, d.Strategy(), StrategyShift)
+		}
+	}
+	for _, s := range []Strategy{StrategyShift, StrategyHardware + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SetAutoStrategy(%v) did not panic", s)
+				}
+			}()
+			SetAutoStrategy(s)
+		}()
+	}
+}
+
+func BenchmarkAutoUint64Div(b *testing.B) {
+	d := NewAutoUint64(varUint64)
+	for i := 0; i < b.N; i++ {
+		sinkUint64 = d.Div(sinkUint64)
+	}
+}
+
+func BenchmarkAutoUint64Mod(b *testing.B) {
+	d := NewAutoUint64(varUint64)
+	for i := 0; i < b.N; i++ {
+		sinkUint64 = d.Mod(sinkUint64)
+	}
+}
+
+func BenchmarkCalibrate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		calibrate()
+	}
+}
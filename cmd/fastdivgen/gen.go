@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"math/bits"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// config describes one generated file.
+type config struct {
+	Package  string
+	Type     string // uint32 or uint64
+	Divisors []uint64
+	Command  string // command line recorded in the header
+}
+
+// divisor holds the constants inlined into the functions for one divisor.
+type divisor struct {
+	D     uint64
+	Pow2  bool
+	Shift int
+	M     uint64 // inverse of a uint32 divisor, as computed by fastdiv.NewUint32
+	Hi    uint64 // inverse of a uint64 divisor, as computed by fastdiv.NewUint64
+	Lo    uint64
+	HiLim uint64 // Hi:Lo - 1, the limit of the divisibility check
+	LoLim uint64
+}
+
+// parseDivisors parses a comma separated list of divisors that fit in typ.
+func parseDivisors(list, typ string) ([]uint64, error) {
+	width, err := typeWidth(typ)
+	if err != nil {
+		return nil, err
+	}
+	var ds []uint64
+	seen := make(map[uint64]bool)
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		d, err := strconv.ParseUint(s, 0, width)
+		if err != nil {
+			return nil, fmt.Errorf("invalid divisor %q for %s: %w", s, typ, err)
+		}
+		if d == 0 {
+			return nil, errors.New("zero divisor")
+		}
+		if seen[d] {
+			return nil, fmt.Errorf("duplicate divisor %d", d)
+		}
+		seen[d] = true
+		ds = append(ds, d)
+	}
+	if len(ds) == 0 {
+		return nil, errors.New("no divisors")
+	}
+	return ds, nil
+}
+
+// typeWidth returns the width in bits of a supported type.
+func typeWidth(typ string) (int, error) {
+	switch typ {
+	case "uint32":
+		return 32, nil
+	case "uint64":
+		return 64, nil
+	}
+	return 0, fmt.Errorf("unsupported type %q, want uint32 or uint64", typ)
+}
+
+// newDivisor pre-computes the constants for d.
+func newDivisor(d uint64) divisor {
+	v := divisor{D: d, Pow2: d&(d-1) == 0, Shift: bits.TrailingZeros64(d)}
+	// the same inverses as NewUint32 and NewUint64, wrapping to zero for d == 1
+	v.M = ^uint64(0)/d + 1
+	hi, r := ^uint64(0)/d, ^uint64(0)%d
+	lo, _ := bits.Div64(r, ^uint64(0), d)
+	var c uint64
+	v.Lo, c = bits.Add64(lo, 1, 0)
+	v.Hi = hi + c
+	var b uint64
+	v.LoLim, b = bits.Sub64(v.Lo, 1, 0)
+	v.HiLim = v.Hi - b
+	return v
+}
+
+// generate returns the formatted source of the file described by cfg.
+func generate(cfg config) ([]byte, error) {
+	if _, err := typeWidth(cfg.Type); err != nil {
+		return nil, err
+	}
+	if cfg.Package == "" {
+		return nil, errors.New("no package name")
+	}
+	ds := make([]divisor, len(cfg.Divisors))
+	needBits := false
+	for i, d := range cfg.Divisors {
+		ds[i] = newDivisor(d)
+		needBits = needBits || !ds[i].Pow2
+	}
+
+	var buf bytes.Buffer
+	err := fileTemplate.Execute(&buf, struct {
+		config
+		Name     string
+		Divs     []divisor
+		NeedBits bool
+	}{cfg, strings.ToUpper(cfg.Type[:1]) + cfg.Type[1:], ds, needBits})
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"hex": func(v uint64) string { return fmt.Sprintf("%#x", v) },
+	"dec": func(v uint64) uint64 { return v - 1 },
+}).Parse(`// Code generated by {{.Command}}; DO NOT EDIT.
+
+package {{.Package}}
+{{if .NeedBits}}
+import "math/bits"
+{{end}}
+{{- range .Divs}}
+// DivBy{{.D}}{{$.Name}} returns n / {{.D}}.
+func DivBy{{.D}}{{$.Name}}(n {{$.Type}}) {{$.Type}} {
+{{- if .Pow2}}
+	return n >> {{.Shift}}
+{{- else if eq $.Type "uint32"}}
+	hi, _ := bits.Mul64({{hex .M}}, uint64(n))
+	return uint32(hi)
+{{- else}}
+	divlo1, _ := bits.Mul64({{hex .Lo}}, n)
+	div, divlo2 := bits.Mul64({{hex .Hi}}, n)
+	_, c := bits.Add64(divlo1, divlo2, 0)
+	return div + c
+{{- end}}
+}
+
+// ModBy{{.D}}{{$.Name}} returns n % {{.D}}.
+func ModBy{{.D}}{{$.Name}}(n {{$.Type}}) {{$.Type}} {
+{{- if .Pow2}}
+	return n & {{hex (dec .D)}}
+{{- else if eq $.Type "uint32"}}
+	mod, _ := bits.Mul64({{hex .M}}*uint64(n), {{.D}})
+	return uint32(mod)
+{{- else}}
+	hi, lo := bits.Mul64({{hex .Lo}}, n)
+	hi += {{hex .Hi}} * n
+	modlo1, _ := bits.Mul64(lo, {{.D}})
+	mod, modlo2 := bits.Mul64(hi, {{.D}})
+	_, c := bits.Add64(modlo1, modlo2, 0)
+	return mod + c
+{{- end}}
+}
+
+// DivisibleBy{{.D}}{{$.Name}} reports whether n is exactly divisible by {{.D}}.
+func DivisibleBy{{.D}}{{$.Name}}(n {{$.Type}}) bool {
+{{- if .Pow2}}
+	return n&{{hex (dec .D)}} == 0
+{{- else if eq $.Type "uint32"}}
+	return {{hex .M}}*uint64(n) <= {{hex (dec .M)}}
+{{- else}}
+	hi, lo := bits.Mul64({{hex .Lo}}, n)
+	hi += {{hex .Hi}} * n
+	return hi < {{hex .HiLim}} || hi == {{hex .HiLim}} && lo <= {{hex .LoLim}}
+{{- end}}
+}
+{{end}}
+// Div{{.Name}} returns n / d, using the functions above for the generated divisors
+// and the division instruction for any other d.
+func Div{{.Name}}(n, d {{.Type}}) {{.Type}} {
+	switch d {
+{{- range .Divs}}
+	case {{.D}}:
+		return DivBy{{.D}}{{$.Name}}(n)
+{{- end}}
+	}
+	return n / d
+}
+
+// Mod{{.Name}} returns n % d, using the functions above for the generated divisors
+// and the division instruction for any other d.
+func Mod{{.Name}}(n, d {{.Type}}) {{.Type}} {
+	switch d {
+{{- range .Divs}}
+	case {{.D}}:
+		return ModBy{{.D}}{{$.Name}}(n)
+{{- end}}
+	}
+	return n % d
+}
+
+// Divisible{{.Name}} reports whether n is exactly divisible by d, using the functions
+// above for the generated divisors and the division instruction for any other d.
+func Divisible{{.Name}}(n, d {{.Type}}) bool {
+	switch d {
+{{- range .Divs}}
+	case {{.D}}:
+		return DivisibleBy{{.D}}{{$.Name}}(n)
+{{- end}}
+	}
+	return n%d == 0
+}
+`))
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"math/bits"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/bmkessler/fastdiv"
+)
+
+func TestConstantsMatchFastdiv(t *testing.T) {
+	checkUint32 := func(n, d uint32) bool {
+		if d == 0 {
+			return true
+		}
+		v := newDivisor(uint64(d))
+		if v.Pow2 {
+			return n>>v.Shift == fastdiv.NewUint32(d).Div(n)
+		}
+		hi, _ := bits.Mul64(v.M, uint64(n))
+		return uint32(hi) == fastdiv.NewUint32(d).Div(n)
+	}
+	if err := quick.Check(checkUint32, nil); err != nil {
+		t.Error(err)
+	}
+
+	checkUint64 := func(n, d uint64) bool {
+		if d == 0 {
+			return true
+		}
+		v := newDivisor(d)
+		if v.Pow2 {
+			return n>>v.Shift == fastdiv.NewUint64(d).Div(n)
+		}
+		divlo1, _ := bits.Mul64(v.Lo, n)
+		div, divlo2 := bits.Mul64(v.Hi, n)
+		_, c := bits.Add64(divlo1, divlo2, 0)
+		return div+c == fastdiv.NewUint64(d).Div(n)
+	}
+	if err := quick.Check(checkUint64, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseDivisors(t *testing.T) {
+	ds, err := parseDivisors(" 7, 0x10,1000000007 ", "uint32")
+	if err != nil || len(ds) != 3 || ds[0] != 7 || ds[1] != 16 || ds[2] != 1000000007 {
+		t.Errorf("got %v, %v", ds, err)
+	}
+	for _, tc := range []struct{ list, typ string }{
+		{"", "uint32"},
+		{"0", "uint32"},
+		{"7,7", "uint32"},
+		{"4294967296", "uint32"},
+		{"-3", "uint64"},
+		{"seven", "uint64"},
+		{"7", "int32"},
+	} {
+		if _, err := parseDivisors(tc.list, tc.typ); err == nil {
+			t.Errorf("parseDivisors(%q, %q): expected an error", tc.list, tc.typ)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate(config{Package: "shard", Type: "uint32", Divisors: []uint64{7, 16}, Command: "fastdivgen"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "divby_uint32.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Name.Name != "shard" {
+		t.Errorf("package %s, want shard", f.Name.Name)
+	}
+	for _, want := range []string{
+		"DivBy7Uint32", "ModBy7Uint32", "DivisibleBy7Uint32",
+		"DivBy16Uint32", "ModBy16Uint32", "DivisibleBy16Uint32",
+		"DivUint32", "ModUint32", "DivisibleUint32",
+	} {
+		if f.Scope.Lookup(want) == nil {
+			t.Errorf("missing %s", want)
+		}
+	}
+	if !strings.Contains(string(src), "return n >> 4") {
+		t.Errorf("power of two divisor does not use a shift:\n%s", src)
+	}
+
+	// a file with only powers of two must not import math/bits
+	src, err = generate(config{Package: "shard", Type: "uint64", Divisors: []uint64{1, 4096}, Command: "fastdivgen"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(src), "math/bits") {
+		t.Errorf("unused import of math/bits:\n%s", src)
+	}
+
+	if _, err := generate(config{Type: "uint32", Divisors: []uint64{7}}); err == nil {
+		t.Error("expected an error for a missing package name")
+	}
+}
+
+// checkProgram runs the generated functions against the division operators.
+const checkProgram = `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	failed := false
+	for _, d := range divisors {
+		for _, n := range numerators(d) {
+			if Div%[1]s(n, d) != n/d || Mod%[1]s(n, d) != n%%d || Divisible%[1]s(n, d) != (n%%d == 0) {
+				fmt.Printf("%%d / %%d: got %%d, %%d, %%t\n", n, d, Div%[1]s(n, d), Mod%[1]s(n, d), Divisible%[1]s(n, d))
+				failed = true
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+var divisors = []%[2]s{%[3]s}
+
+func numerators(d %[2]s) []%[2]s {
+	ns := []%[2]s{0, 1, d - 1, d, d + 1, 2*d - 1, 2 * d, ^%[2]s(0), ^%[2]s(0) - 1, ^%[2]s(0) / d * d, ^%[2]s(0)/d*d - 1}
+	x := %[2]s(0x9e3779b97f4a7c15 & uint64(^%[2]s(0)))
+	for i := 0; i < 1000; i++ {
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+		ns = append(ns, x, x/d*d)
+	}
+	return ns
+}
+`
+
+func TestGeneratedCode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go run in short mode")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	for _, tc := range []struct {
+		typ  string
+		list string
+	}{
+		{"uint32", "1,2,3,5,6,7,10,13,16,100,641,1000,1024,4096,65535,1000000007,2147483648,4294967295"},
+		{"uint64", "1,2,3,5,6,7,10,13,16,100,641,1000,1024,1000000007,4294967296,6700417,9223372036854775807,9223372036854775808,18446744073709551615"},
+	} {
+		t.Run(tc.typ, func(t *testing.T) {
+			dir := t.TempDir()
+			out := filepath.Join(dir, "divby_"+tc.typ+".go")
+			if err := run([]string{"-type", tc.typ, "-divisors", tc.list, "-package", "main", "-output", out}); err != nil {
+				t.Fatal(err)
+			}
+			name := strings.ToUpper(tc.typ[:1]) + tc.typ[1:]
+			check := filepath.Join(dir, "check.go")
+			prog := fmt.Sprintf(checkProgram, name, tc.typ, tc.list)
+			if err := os.WriteFile(check, []byte(prog), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cmd := exec.Command(gobin, "run", check, out)
+			cmd.Dir = dir
+			cmd.Env = append(os.Environ(), "GO111MODULE=off")
+			if b, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("%v\n%s", err, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Fastdivgen generates Go functions that divide by a fixed set of divisors
+with the pre-computed inverses of fastdiv.NewUint32 and fastdiv.NewUint64
+inlined as constants, so the hot path loads no divisor struct.
+
+Usage:
+
+	fastdivgen -type uint32 -divisors 7,10,1024 [-package name] [-output file]
+
+For every divisor d it writes DivByDUint32, ModByDUint32 and DivisibleByDUint32,
+followed by DivUint32, ModUint32 and DivisibleUint32, which switch on d and
+fall back to the division instruction for any other divisor.
+Powers of two use a shift and a mask.
+
+It is meant to be run by go generate:
+
+	//go:generate fastdivgen -type uint32 -divisors 7,10,1024
+
+The package defaults to $GOPACKAGE and the output to divby_<type>.go.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "fastdivgen:", err)
+		os.Exit(2)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("fastdivgen", flag.ContinueOnError)
+	typ := fs.String("type", "uint32", "dividend and divisor type: uint32 or uint64")
+	list := fs.String("divisors", "", "comma separated list of divisors")
+	pkg := fs.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	output := fs.String("output", "", "output file name; default divby_<type>.go")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+
+	divisors, err := parseDivisors(*list, *typ)
+	if err != nil {
+		return err
+	}
+	src, err := generate(config{
+		Package:  *pkg,
+		Type:     *typ,
+		Divisors: divisors,
+		Command:  "fastdivgen " + strings.Join(args, " "),
+	})
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		*output = "divby_" + *typ + ".go"
+	}
+	return os.WriteFile(*output, src, 0o644)
+}
@@ -0,0 +1,419 @@
+package fastdiv
+
+import "unsafe"
+
+// Integer is the set of integer types supported by Divisor.
+type Integer interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uint | ~uintptr |
+		~int8 | ~int16 | ~int32 | ~int64 | ~int
+}
+
+// Divisor calculates division of any integer type by using a pre-computed inverse.
+// The width and signedness of T select the same algorithm as the concrete
+// type of that width, e.g. a Divisor[uint32] behaves exactly like a Uint32.
+type Divisor[T Integer] struct {
+	d      uint64 // divisor, or its absolute value for signed types
+	hi, lo uint64 // pre-computed inverse, lo holds m for widths <= 32 bits
+	neg    bool
+	shift  uint8 // log2(d) if pow2
+	pow2   bool
+}
+
+// New initializes a new pre-computed inverse for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+func New[T Integer](d T) Divisor[T] {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(d) {
+		case 1:
+			v := NewInt8(int8(d))
+			return Divisor[T]{d: uint64(v.absd), lo: uint64(v.m), neg: v.neg, shift: v.shift, pow2: v.pow2}
+		case 2:
+			v := NewInt16(int16(d))
+			return Divisor[T]{d: uint64(v.absd), lo: uint64(v.m), neg: v.neg, shift: v.shift, pow2: v.pow2}
+		case 4:
+			v := NewInt32(int32(d))
+			return Divisor[T]{d: v.absd, lo: v.m, neg: v.neg, shift: v.shift, pow2: v.pow2}
+		default:
+			v := NewInt64(int64(d))
+			return Divisor[T]{d: v.absd, hi: v.hi, lo: v.lo, neg: v.neg, shift: v.shift, pow2: v.pow2}
+		}
+	}
+	switch unsafe.Sizeof(d) {
+	case 1:
+		v := NewUint8(uint8(d))
+		return Divisor[T]{d: uint64(v.d), lo: uint64(v.m), shift: v.shift, pow2: v.pow2}
+	case 2:
+		v := NewUint16(uint16(d))
+		return Divisor[T]{d: uint64(v.d), lo: uint64(v.m), shift: v.shift, pow2: v.pow2}
+	case 4:
+		v := NewUint32(uint32(d))
+		return Divisor[T]{d: v.d, lo: v.m, shift: v.shift, pow2: v.pow2}
+	default:
+		v := NewUint64(uint64(d))
+		return Divisor[T]{d: v.d, hi: v.hi, lo: v.lo, shift: v.shift, pow2: v.pow2}
+	}
+}
+
+// TryNew initializes a new pre-computed inverse like New,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNew[T Integer](d T) (Divisor[T], error) {
+	if d == 0 {
+		return Divisor[T]{}, ErrZeroDivisor
+	}
+	return New(d), nil
+}
+
+// Div calculates n / d using the pre-computed inverse.
+// The restrictions on d are those of the concrete type of the same width.
+func (d Divisor[T]) Div(n T) T {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(n) {
+		case 1:
+			return T(d.int8().Div(int8(n)))
+		case 2:
+			return T(d.int16().Div(int16(n)))
+		case 4:
+			return T(d.int32().Div(int32(n)))
+		default:
+			return T(d.int64().Div(int64(n)))
+		}
+	}
+	switch unsafe.Sizeof(n) {
+	case 1:
+		return T(d.uint8().Div(uint8(n)))
+	case 2:
+		return T(d.uint16().Div(uint16(n)))
+	case 4:
+		return T(d.uint32().Div(uint32(n)))
+	default:
+		return T(d.uint64().Div(uint64(n)))
+	}
+}
+
+// Mod calculates n % d using the pre-computed inverse.
+func (d Divisor[T]) Mod(n T) T {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(n) {
+		case 1:
+			return T(d.int8().Mod(int8(n)))
+		case 2:
+			return T(d.int16().Mod(int16(n)))
+		case 4:
+			return T(d.int32().Mod(int32(n)))
+		default:
+			return T(d.int64().Mod(int64(n)))
+		}
+	}
+	switch unsafe.Sizeof(n) {
+	case 1:
+		return T(d.uint8().Mod(uint8(n)))
+	case 2:
+		return T(d.uint16().Mod(uint16(n)))
+	case 4:
+		return T(d.uint32().Mod(uint32(n)))
+	default:
+		return T(d.uint64().Mod(uint64(n)))
+	}
+}
+
+// DivMod calculates n / d and n % d using the pre-computed inverse.
+// The restrictions on d are those of the concrete type of the same width.
+func (d Divisor[T]) DivMod(n T) (q, r T) {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(n) {
+		case 1:
+			q, r := d.int8().DivMod(int8(n))
+			return T(q), T(r)
+		case 2:
+			q, r := d.int16().DivMod(int16(n))
+			return T(q), T(r)
+		case 4:
+			q, r := d.int32().DivMod(int32(n))
+			return T(q), T(r)
+		default:
+			q, r := d.int64().DivMod(int64(n))
+			return T(q), T(r)
+		}
+	}
+	switch unsafe.Sizeof(n) {
+	case 1:
+		q, r := d.uint8().DivMod(uint8(n))
+		return T(q), T(r)
+	case 2:
+		q, r := d.uint16().DivMod(uint16(n))
+		return T(q), T(r)
+	case 4:
+		q, r := d.uint32().DivMod(uint32(n))
+		return T(q), T(r)
+	default:
+		q, r := d.uint64().DivMod(uint64(n))
+		return T(q), T(r)
+	}
+}
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Divisor[T]) Divisible(n T) bool {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(n) {
+		case 1:
+			return d.int8().Divisible(int8(n))
+		case 2:
+			return d.int16().Divisible(int16(n))
+		case 4:
+			return d.int32().Divisible(int32(n))
+		default:
+			return d.int64().Divisible(int64(n))
+		}
+	}
+	switch unsafe.Sizeof(n) {
+	case 1:
+		return d.uint8().Divisible(uint8(n))
+	case 2:
+		return d.uint16().Divisible(uint16(n))
+	case 4:
+		return d.uint32().Divisible(uint32(n))
+	default:
+		return d.uint64().Divisible(uint64(n))
+	}
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Divisor[T]) DivRound(n T, mode RoundingMode) T {
+	q, r := d.DivMod(n)
+	return round(q, r, d.d, d.neg, mode)
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Divisor[T]) DivSlice(dst, src []T) {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(T(0)) {
+		case 1:
+			d.int8().DivSlice(castSlice[int8](dst), castSlice[int8](src))
+		case 2:
+			d.int16().DivSlice(castSlice[int16](dst), castSlice[int16](src))
+		case 4:
+			d.int32().DivSlice(castSlice[int32](dst), castSlice[int32](src))
+		default:
+			d.int64().DivSlice(castSlice[int64](dst), castSlice[int64](src))
+		}
+		return
+	}
+	switch unsafe.Sizeof(T(0)) {
+	case 1:
+		d.uint8().DivSlice(castSlice[uint8](dst), castSlice[uint8](src))
+	case 2:
+		d.uint16().DivSlice(castSlice[uint16](dst), castSlice[uint16](src))
+	case 4:
+		d.uint32().DivSlice(castSlice[uint32](dst), castSlice[uint32](src))
+	default:
+		d.uint64().DivSlice(castSlice[uint64](dst), castSlice[uint64](src))
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Divisor[T]) ModSlice(dst, src []T) {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(T(0)) {
+		case 1:
+			d.int8().ModSlice(castSlice[int8](dst), castSlice[int8](src))
+		case 2:
+			d.int16().ModSlice(castSlice[int16](dst), castSlice[int16](src))
+		case 4:
+			d.int32().ModSlice(castSlice[int32](dst), castSlice[int32](src))
+		default:
+			d.int64().ModSlice(castSlice[int64](dst), castSlice[int64](src))
+		}
+		return
+	}
+	switch unsafe.Sizeof(T(0)) {
+	case 1:
+		d.uint8().ModSlice(castSlice[uint8](dst), castSlice[uint8](src))
+	case 2:
+		d.uint16().ModSlice(castSlice[uint16](dst), castSlice[uint16](src))
+	case 4:
+		d.uint32().ModSlice(castSlice[uint32](dst), castSlice[uint32](src))
+	default:
+		d.uint64().ModSlice(castSlice[uint64](dst), castSlice[uint64](src))
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Divisor[T]) DivModSlice(q, r, src []T) {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(T(0)) {
+		case 1:
+			d.int8().DivModSlice(castSlice[int8](q), castSlice[int8](r), castSlice[int8](src))
+		case 2:
+			d.int16().DivModSlice(castSlice[int16](q), castSlice[int16](r), castSlice[int16](src))
+		case 4:
+			d.int32().DivModSlice(castSlice[int32](q), castSlice[int32](r), castSlice[int32](src))
+		default:
+			d.int64().DivModSlice(castSlice[int64](q), castSlice[int64](r), castSlice[int64](src))
+		}
+		return
+	}
+	switch unsafe.Sizeof(T(0)) {
+	case 1:
+		d.uint8().DivModSlice(castSlice[uint8](q), castSlice[uint8](r), castSlice[uint8](src))
+	case 2:
+		d.uint16().DivModSlice(castSlice[uint16](q), castSlice[uint16](r), castSlice[uint16](src))
+	case 4:
+		d.uint32().DivModSlice(castSlice[uint32](q), castSlice[uint32](r), castSlice[uint32](src))
+	default:
+		d.uint64().DivModSlice(castSlice[uint64](q), castSlice[uint64](r), castSlice[uint64](src))
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Divisor[T]) DivisibleMask(dst []uint64, src []T) {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(T(0)) {
+		case 1:
+			d.int8().DivisibleMask(dst, castSlice[int8](src))
+		case 2:
+			d.int16().DivisibleMask(dst, castSlice[int16](src))
+		case 4:
+			d.int32().DivisibleMask(dst, castSlice[int32](src))
+		default:
+			d.int64().DivisibleMask(dst, castSlice[int64](src))
+		}
+		return
+	}
+	switch unsafe.Sizeof(T(0)) {
+	case 1:
+		d.uint8().DivisibleMask(dst, castSlice[uint8](src))
+	case 2:
+		d.uint16().DivisibleMask(dst, castSlice[uint16](src))
+	case 4:
+		d.uint32().DivisibleMask(dst, castSlice[uint32](src))
+	default:
+		d.uint64().DivisibleMask(dst, castSlice[uint64](src))
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Divisor[T]) FilterDivisible(s []T) []T {
+	if isSigned[T]() {
+		switch unsafe.Sizeof(T(0)) {
+		case 1:
+			return s[:len(d.int8().FilterDivisible(castSlice[int8](s)))]
+		case 2:
+			return s[:len(d.int16().FilterDivisible(castSlice[int16](s)))]
+		case 4:
+			return s[:len(d.int32().FilterDivisible(castSlice[int32](s)))]
+		default:
+			return s[:len(d.int64().FilterDivisible(castSlice[int64](s)))]
+		}
+	}
+	switch unsafe.Sizeof(T(0)) {
+	case 1:
+		return s[:len(d.uint8().FilterDivisible(castSlice[uint8](s)))]
+	case 2:
+		return s[:len(d.uint16().FilterDivisible(castSlice[uint16](s)))]
+	case 4:
+		return s[:len(d.uint32().FilterDivisible(castSlice[uint32](s)))]
+	default:
+		return s[:len(d.uint64().FilterDivisible(castSlice[uint64](s)))]
+	}
+}
+
+// FloorDiv calculates n / d rounded toward negative infinity using the pre-computed inverse.
+// For unsigned types it is the same as Div.
+func (d Divisor[T]) FloorDiv(n T) T {
+	q, _ := d.FloorDivMod(n)
+	return q
+}
+
+// FloorMod calculates n mod d with the sign of d using the pre-computed inverse.
+// For unsigned types it is the same as Mod.
+func (d Divisor[T]) FloorMod(n T) T {
+	_, r := d.FloorDivMod(n)
+	return r
+}
+
+// FloorDivMod calculates n / d rounded toward negative infinity and n mod d with the sign of d
+// using the pre-computed inverse. For unsigned types it is the same as DivMod.
+func (d Divisor[T]) FloorDivMod(n T) (q, r T) {
+	q, r = d.DivMod(n)
+	if r != 0 && (r < 0) != d.neg {
+		return q - 1, r + d.value()
+	}
+	return q, r
+}
+
+// EuclidDiv calculates the Euclidean quotient of n / d using the pre-computed inverse.
+// For unsigned types it is the same as Div.
+func (d Divisor[T]) EuclidDiv(n T) T {
+	q, _ := d.EuclidDivMod(n)
+	return q
+}
+
+// EuclidMod calculates the non-negative remainder of n / d using the pre-computed inverse.
+// For unsigned types it is the same as Mod.
+func (d Divisor[T]) EuclidMod(n T) T {
+	_, r := d.EuclidDivMod(n)
+	return r
+}
+
+// EuclidDivMod calculates the Euclidean quotient and the non-negative remainder of n / d
+// using the pre-computed inverse. For unsigned types it is the same as DivMod.
+func (d Divisor[T]) EuclidDivMod(n T) (q, r T) {
+	q, r = d.DivMod(n)
+	if r < 0 {
+		if d.neg {
+			return q + 1, r + T(d.d)
+		}
+		return q - 1, r + T(d.d)
+	}
+	return q, r
+}
+
+// value returns the signed divisor d.
+func (d Divisor[T]) value() T {
+	if d.neg {
+		return -T(d.d)
+	}
+	return T(d.d)
+}
+
+// isSigned reports whether T is a signed integer type.
+func isSigned[T Integer]() bool {
+	return ^T(0) < 0
+}
+
+func (d Divisor[T]) uint8() Uint8 {
+	return Uint8{d: uint16(d.d), m: uint16(d.lo), shift: d.shift, pow2: d.pow2}
+}
+
+func (d Divisor[T]) uint16() Uint16 {
+	return Uint16{d: uint32(d.d), m: uint32(d.lo), shift: d.shift, pow2: d.pow2}
+}
+
+func (d Divisor[T]) uint32() Uint32 {
+	return Uint32{d: d.d, m: d.lo, shift: d.shift, pow2: d.pow2}
+}
+
+func (d Divisor[T]) uint64() Uint64 {
+	return Uint64{d: d.d, hi: d.hi, lo: d.lo, shift: d.shift, pow2: d.pow2}
+}
+
+func (d Divisor[T]) int8() Int8 {
+	return Int8{absd: uint16(d.d), m: uint16(d.lo), neg: d.neg, shift: d.shift, pow2: d.pow2}
+}
+
+func (d Divisor[T]) int16() Int16 {
+	return Int16{absd: uint32(d.d), m: uint32(d.lo), neg: d.neg, shift: d.shift, pow2: d.pow2}
+}
+
+func (d Divisor[T]) int32() Int32 {
+	return Int32{absd: d.d, m: d.lo, neg: d.neg, shift: d.shift, pow2: d.pow2}
+}
+
+func (d Divisor[T]) int64() Int64 {
+	return Int64{absd: d.d, hi: d.hi, lo: d.lo, neg: d.neg, shift: d.shift, pow2: d.pow2}
+}
@@ -0,0 +1,179 @@
+package fastdiv
+
+import (
+	"testing"
+	"testing/quick"
+	"unsafe"
+)
+
+type shardID uint32
+
+var (
+	sinkDivisorUint32 = shardID(^uint32(0))
+	varDivisorUint32  = shardID(123456789)
+)
+
+func testDivisor[T Integer](t *testing.T) {
+	checkDiv := func(x, y T) bool {
+		if y == 0 {
+			return true
+		}
+		d := New(y)
+		return (x / y) == d.Div(x)
+	}
+	if err := quick.Check(checkDiv, nil); err != nil {
+		t.Error(err)
+	}
+
+	checkMod := func(x, y T) bool {
+		if y == 0 {
+			return true
+		}
+		d := New(y)
+		return (x % y) == d.Mod(x)
+	}
+	if err := quick.Check(checkMod, nil); err != nil {
+		t.Error(err)
+	}
+
+	checkDivMod := func(x, y T) bool {
+		if y == 0 {
+			return true
+		}
+		d := New(y)
+		q, r := d.DivMod(x)
+		return (x/y) == q && (x%y) == r
+	}
+	if err := quick.Check(checkDivMod, nil); err != nil {
+		t.Error(err)
+	}
+
+	checkDivisible := func(x, y T) bool {
+		if y == 0 {
+			return true
+		}
+		d := New(y)
+		return ((x % y) == 0) == d.Divisible(x)
+	}
+	if err := quick.Check(checkDivisible, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDivisor(t *testing.T) {
+	t.Run("uint8", testDivisor[uint8])
+	t.Run("uint16", testDivisor[uint16])
+	t.Run("uint32", testDivisor[uint32])
+	t.Run("uint64", testDivisor[uint64])
+	t.Run("uint", testDivisor[uint])
+	t.Run("uintptr", testDivisor[uintptr])
+	t.Run("int8", testDivisor[int8])
+	t.Run("int16", testDivisor[int16])
+	t.Run("int32", testDivisor[int32])
+	t.Run("int64", testDivisor[int64])
+	t.Run("int", testDivisor[int])
+	t.Run("shardID", testDivisor[shardID])
+}
+
+func TestDivisorMatchesConcrete(t *testing.T) {
+	checkUint32 := func(x, y uint32) bool {
+		if y == 0 {
+			return true
+		}
+		d, c := New(shardID(y)), NewUint32(y)
+		q, r := d.DivMod(shardID(x))
+		cq, cr := c.DivMod(x)
+		return uint32(d.Div(shardID(x))) == c.Div(x) &&
+			uint32(d.Mod(shardID(x))) == c.Mod(x) &&
+			uint32(q) == cq && uint32(r) == cr &&
+			d.Divisible(shardID(x)) == c.Divisible(x)
+	}
+	if err := quick.Check(checkUint32, nil); err != nil {
+		t.Error(err)
+	}
+
+	checkInt64 := func(x, y int64) bool {
+		if y == 0 {
+			return true
+		}
+		d, c := New(y), NewInt64(y)
+		q, r := d.DivMod(x)
+		cq, cr := c.DivMod(x)
+		return d.Div(x) == c.Div(x) && d.Mod(x) == c.Mod(x) && q == cq && r == cr &&
+			d.Divisible(x) == c.Divisible(x)
+	}
+	if err := quick.Check(checkInt64, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// testPow2 checks every divisor ±2^k of T, which take the shift and mask path,
+// against numerators around every power of two and the extremes of T.
+func testPow2[T Integer](t *testing.T) {
+	bits := 8 * int(unsafe.Sizeof(T(0)))
+	var xs []T
+	for k := 0; k < bits; k++ {
+		p := T(1) << k
+		xs = append(xs, p, p-1, p+1, -p, -p-1, -p+1)
+	}
+	xs = append(xs, 0, ^T(0))
+	for k := 0; k < bits; k++ {
+		for _, y := range []T{T(1) << k, -(T(1) << k)} {
+			if y > 0 && y<<1 == 0 && isSigned[T]() {
+				continue // -(MinInt) wraps to the same divisor
+			}
+			if y < 0 && !isSigned[T]() {
+				continue
+			}
+			d := New(y)
+			for _, x := range xs {
+				if isSigned[T]() && y == ^T(0) && x<<1 == 0 && x != 0 {
+					continue // MinInt / -1 overflows
+				}
+				q, r := d.DivMod(x)
+				if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+					t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, x/y, x%y)
+				}
+			}
+		}
+	}
+}
+
+func TestDivisorPow2(t *testing.T) {
+	t.Run("uint8", testPow2[uint8])
+	t.Run("uint16", testPow2[uint16])
+	t.Run("uint32", testPow2[uint32])
+	t.Run("uint64", testPow2[uint64])
+	t.Run("int8", testPow2[int8])
+	t.Run("int16", testPow2[int16])
+	t.Run("int32", testPow2[int32])
+	t.Run("int64", testPow2[int64])
+}
+
+func BenchmarkDivisorUint32Div(b *testing.B) {
+	d := New(varDivisorUint32)
+	for i := 0; i < b.N; i++ {
+		sinkDivisorUint32 = d.Div(sinkDivisorUint32)
+	}
+}
+
+func BenchmarkDivisorUint32Mod(b *testing.B) {
+	d := New(varDivisorUint32)
+	for i := 0; i < b.N; i++ {
+		sinkDivisorUint32 = d.Mod(sinkDivisorUint32)
+	}
+}
+
+func BenchmarkDivisorInt64Div(b *testing.B) {
+	d := New(varInt64)
+	for i := 0; i < b.N; i++ {
+		sinkInt64 = d.Div(sinkInt64)
+	}
+}
+
+func BenchmarkDivisorInt64Mod(b *testing.B) {
+	d := New(varInt64)
+	for i := 0; i < b.N; i++ {
+		sinkInt64 = d.Mod(sinkInt64)
+	}
+}
@@ -0,0 +1,175 @@
+package fastdiv
+
+import "math/bits"
+
+// DivisorSet32 tests a uint32 against a list of divisors with their pre-computed inverses.
+// The inverses are stored struct-of-arrays, so that a test of all divisors is a tight
+// loop over a slice instead of a method call per Uint32.
+type DivisorSet32 struct {
+	d []uint32
+	m []uint64
+}
+
+// NewDivisorSet32 initializes the pre-computed inverses for the divisors in order.
+// If any divisor is 0, a runtime divide-by-zero panic is raised.
+func NewDivisorSet32(divisors []uint32) DivisorSet32 {
+	s := DivisorSet32{d: make([]uint32, len(divisors)), m: make([]uint64, len(divisors))}
+	for i, d := range divisors {
+		s.d[i], s.m[i] = d, NewUint32(d).m
+	}
+	return s
+}
+
+// TryNewDivisorSet32 initializes the pre-computed inverses like NewDivisorSet32,
+// but returns ErrZeroDivisor instead of panicking if any divisor is 0.
+func TryNewDivisorSet32(divisors []uint32) (DivisorSet32, error) {
+	for _, d := range divisors {
+		if d == 0 {
+			return DivisorSet32{}, ErrZeroDivisor
+		}
+	}
+	return NewDivisorSet32(divisors), nil
+}
+
+// Len returns the number of divisors.
+func (s DivisorSet32) Len() int {
+	return len(s.d)
+}
+
+// Divisor returns the i-th divisor.
+func (s DivisorSet32) Divisor(i int) uint32 {
+	return s.d[i]
+}
+
+// AnyDivides reports whether n is exactly divisible by any of the divisors.
+func (s DivisorSet32) AnyDivides(n uint32) bool {
+	return s.FirstDivisor(n) >= 0
+}
+
+// AllDivide reports whether n is exactly divisible by all of the divisors.
+// It is true for an empty set.
+func (s DivisorSet32) AllDivide(n uint32) bool {
+	for _, m := range s.m {
+		if m*uint64(n) > m-1 {
+			return false
+		}
+	}
+	return true
+}
+
+// FirstDivisor returns the index of the first divisor that n is exactly divisible by,
+// or -1 if there is none.
+func (s DivisorSet32) FirstDivisor(n uint32) int {
+	for i, m := range s.m {
+		if m*uint64(n) <= m-1 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Residues calculates dst[i] = n % d[i] for every divisor.
+// It panics if len(dst) < s.Len().
+func (s DivisorSet32) Residues(n uint32, dst []uint32) {
+	dst = dst[:len(s.d)]
+	for i, m := range s.m {
+		mod, _ := bits.Mul64(m*uint64(n), uint64(s.d[i]))
+		dst[i] = uint32(mod)
+	}
+}
+
+// DivisorSet64 tests a uint64 against a list of divisors with their pre-computed inverses.
+// The inverses are stored struct-of-arrays, so that a test of all divisors is a tight
+// loop over slices instead of a method call per Uint64.
+type DivisorSet64 struct {
+	d      []uint64
+	hi, lo []uint64
+}
+
+// NewDivisorSet64 initializes the pre-computed inverses for the divisors in order.
+// If any divisor is 0, a runtime divide-by-zero panic is raised.
+func NewDivisorSet64(divisors []uint64) DivisorSet64 {
+	s := DivisorSet64{
+		d:  make([]uint64, len(divisors)),
+		hi: make([]uint64, len(divisors)),
+		lo: make([]uint64, len(divisors)),
+	}
+	for i, d := range divisors {
+		u := NewUint64(d)
+		s.d[i], s.hi[i], s.lo[i] = d, u.hi, u.lo
+	}
+	return s
+}
+
+// TryNewDivisorSet64 initializes the pre-computed inverses like NewDivisorSet64,
+// but returns ErrZeroDivisor instead of panicking if any divisor is 0.
+func TryNewDivisorSet64(divisors []uint64) (DivisorSet64, error) {
+	for _, d := range divisors {
+		if d == 0 {
+			return DivisorSet64{}, ErrZeroDivisor
+		}
+	}
+	return NewDivisorSet64(divisors), nil
+}
+
+// Len returns the number of divisors.
+func (s DivisorSet64) Len() int {
+	return len(s.d)
+}
+
+// Divisor returns the i-th divisor.
+func (s DivisorSet64) Divisor(i int) uint64 {
+	return s.d[i]
+}
+
+// divisible is Uint64.Divisible for the i-th divisor.
+func (s DivisorSet64) divisible(i int, n uint64) bool {
+	hi, lo := bits.Mul64(s.lo[i], n)
+	hi += s.hi[i] * n
+	// the fraction must not exceed the inverse minus one
+	locheck, b := bits.Sub64(s.lo[i], 1, 0)
+	hicheck := s.hi[i] - b
+	return hi < hicheck || hi == hicheck && lo <= locheck
+}
+
+// AnyDivides reports whether n is exactly divisible by any of the divisors.
+func (s DivisorSet64) AnyDivides(n uint64) bool {
+	return s.FirstDivisor(n) >= 0
+}
+
+// AllDivide reports whether n is exactly divisible by all of the divisors.
+// It is true for an empty set.
+func (s DivisorSet64) AllDivide(n uint64) bool {
+	for i := range s.d {
+		if !s.divisible(i, n) {
+			return false
+		}
+	}
+	return true
+}
+
+// FirstDivisor returns the index of the first divisor that n is exactly divisible by,
+// or -1 if there is none.
+func (s DivisorSet64) FirstDivisor(n uint64) int {
+	for i := range s.d {
+		if s.divisible(i, n) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Residues calculates dst[i] = n % d[i] for every divisor.
+// It panics if len(dst) < s.Len().
+func (s DivisorSet64) Residues(n uint64, dst []uint64) {
+	dst = dst[:len(s.d)]
+	hi, lo := s.hi[:len(s.d)], s.lo[:len(s.d)]
+	for i, d := range s.d {
+		fhi, flo := bits.Mul64(lo[i], n)
+		fhi += hi[i] * n
+		modlo1, _ := bits.Mul64(flo, d)
+		mod, modlo2 := bits.Mul64(fhi, d)
+		_, c := bits.Add64(modlo1, modlo2, 0)
+		dst[i] = mod + c
+	}
+}
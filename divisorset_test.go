@@ -0,0 +1,173 @@
+package fastdiv
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+// checkDivisorSet32 compares all methods of a DivisorSet32 with the % operator.
+func checkDivisorSet32(n uint32, divisors []uint32) bool {
+	s := NewDivisorSet32(divisors)
+	if s.Len() != len(divisors) {
+		return false
+	}
+	first, all := -1, true
+	for i, d := range divisors {
+		if s.Divisor(i) != d {
+			return false
+		}
+		if n%d == 0 && first < 0 {
+			first = i
+		}
+		all = all && n%d == 0
+	}
+	res := make([]uint32, len(divisors))
+	s.Residues(n, res)
+	for i, d := range divisors {
+		if res[i] != n%d {
+			return false
+		}
+	}
+	return s.FirstDivisor(n) == first && s.AnyDivides(n) == (first >= 0) && s.AllDivide(n) == all
+}
+
+func TestDivisorSet32(t *testing.T) {
+	check := func(n uint32, divisors []uint32) bool {
+		// mix small and large divisors without zeros
+		for i := range divisors {
+			divisors[i] = divisors[i]>>uint(i%32) | 1<<uint(i%2)
+		}
+		return checkDivisorSet32(n, divisors)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+
+	edges := []uint32{3, 5, 7, 1, math.MaxUint32}
+	for _, n := range []uint32{0, 1, 105, 3 * 5 * 7 * 9, math.MaxUint32} {
+		if !checkDivisorSet32(n, edges) {
+			t.Errorf("n = %d", n)
+		}
+	}
+}
+
+// checkDivisorSet64 compares all methods of a DivisorSet64 with the % operator.
+func checkDivisorSet64(n uint64, divisors []uint64) bool {
+	s := NewDivisorSet64(divisors)
+	if s.Len() != len(divisors) {
+		return false
+	}
+	first, all := -1, true
+	for i, d := range divisors {
+		if s.Divisor(i) != d {
+			return false
+		}
+		if n%d == 0 && first < 0 {
+			first = i
+		}
+		all = all && n%d == 0
+	}
+	res := make([]uint64, len(divisors)+1)
+	s.Residues(n, res)
+	for i, d := range divisors {
+		if res[i] != n%d {
+			return false
+		}
+	}
+	return s.FirstDivisor(n) == first && s.AnyDivides(n) == (first >= 0) && s.AllDivide(n) == all
+}
+
+func TestDivisorSet64(t *testing.T) {
+	check := func(n uint64, divisors []uint64) bool {
+		// mix small and large divisors without zeros
+		for i := range divisors {
+			divisors[i] = divisors[i]>>uint(i%64) | 1<<uint(i%2)
+		}
+		return checkDivisorSet64(n, divisors)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	edges := []uint64{3, 1 << 32, 1, 1<<63 + 1, math.MaxUint64}
+	for _, n := range []uint64{0, 1, 3 << 32, math.MaxUint64, 1<<63 + 1} {
+		if !checkDivisorSet64(n, edges) {
+			t.Errorf("n = %d", n)
+		}
+	}
+}
+
+func TestDivisorSetEmpty(t *testing.T) {
+	s32, s64 := NewDivisorSet32(nil), NewDivisorSet64(nil)
+	if s32.AnyDivides(6) || !s32.AllDivide(6) || s32.FirstDivisor(6) != -1 {
+		t.Error("DivisorSet32: an empty set must divide no n and all divide vacuously")
+	}
+	if s64.AnyDivides(6) || !s64.AllDivide(6) || s64.FirstDivisor(6) != -1 {
+		t.Error("DivisorSet64: an empty set must divide no n and all divide vacuously")
+	}
+	s32.Residues(6, nil)
+	s64.Residues(6, nil)
+}
+
+func TestTryNewDivisorSet(t *testing.T) {
+	if _, err := TryNewDivisorSet32([]uint32{3, 0}); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewDivisorSet32: got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewDivisorSet64([]uint64{0}); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewDivisorSet64: got %v, want %v", err, ErrZeroDivisor)
+	}
+	if s, err := TryNewDivisorSet32([]uint32{3, 5}); err != nil || s.Len() != 2 {
+		t.Errorf("TryNewDivisorSet32: got %d divisors, %v", s.Len(), err)
+	}
+	if s, err := TryNewDivisorSet64([]uint64{3, 5}); err != nil || s.Len() != 2 {
+		t.Errorf("TryNewDivisorSet64: got %d divisors, %v", s.Len(), err)
+	}
+}
+
+var ruleDivisors = []uint32{3, 5, 7, 11, 13, 17, 19, 23}
+
+func BenchmarkDivisorSet32FirstDivisor(b *testing.B) {
+	s := NewDivisorSet32(ruleDivisors)
+	for i := 0; i < b.N; i++ {
+		sinkShard = s.FirstDivisor(uint32(i)*2 + 1)
+	}
+}
+
+func BenchmarkDivisorSet32FirstDivisorUint32(b *testing.B) {
+	ds := make([]Uint32, len(ruleDivisors))
+	for i, d := range ruleDivisors {
+		ds[i] = NewUint32(d)
+	}
+	for i := 0; i < b.N; i++ {
+		first := -1
+		for j, d := range ds {
+			if d.Divisible(uint32(i)*2 + 1) {
+				first = j
+				break
+			}
+		}
+		sinkShard = first
+	}
+}
+
+func BenchmarkDivisorSet32FirstDivisorMod(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		first := -1
+		for j, d := range ruleDivisors {
+			if (uint32(i)*2+1)%d == 0 {
+				first = j
+				break
+			}
+		}
+		sinkShard = first
+	}
+}
+
+func BenchmarkDivisorSet64Residues(b *testing.B) {
+	s := NewDivisorSet64([]uint64{3, 5, 7, 11, 13, 17, 19, 23})
+	dst := make([]uint64, s.Len())
+	for i := 0; i < b.N; i++ {
+		s.Residues(uint64(i)*0x9e3779b97f4a7c15, dst)
+	}
+}
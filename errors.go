@@ -0,0 +1,39 @@
+package fastdiv
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrZeroDivisor is returned when initializing a pre-computed inverse for d == 0.
+var ErrZeroDivisor = errors.New("fastdiv: zero divisor")
+
+// ErrShardCount is returned when initializing a Sharder for a negative number of shards
+// or more than math.MaxUint32.
+var ErrShardCount = errors.New("fastdiv: shard count out of range")
+
+// ErrEvenModulus is returned when initializing Montgomery arithmetic for an even modulus.
+var ErrEvenModulus = errors.New("fastdiv: even Montgomery modulus")
+
+// ErrNotCoprime is returned when initializing an RNS for moduli that share a factor.
+var ErrNotCoprime = errors.New("fastdiv: moduli are not pairwise coprime")
+
+// ErrRNSOverflow is returned when reconstructing a residue vector whose value
+// does not fit in a uint64.
+var ErrRNSOverflow = errors.New("fastdiv: reconstructed value overflows uint64")
+
+// ErrNTTModulus is returned when initializing an NTT for a modulus that is not an odd prime.
+var ErrNTTModulus = errors.New("fastdiv: NTT modulus is not an odd prime")
+
+// errNTTLength is raised as a panic when a transform length is not a power of two
+// that divides p - 1.
+var errNTTLength = errors.New("fastdiv: NTT length is not a power of two dividing p - 1")
+
+// errQuotientOverflow is raised as a panic when a quotient does not fit in the result.
+// It is the runtime.Error that bits.Div64 panics with in the same case.
+var errQuotientOverflow = func() (err error) {
+	defer func() { err = recover().(error) }()
+	hi := uint64(1)
+	bits.Div64(hi, 0, 1)
+	return nil
+}()
@@ -28,3 +28,13 @@ func Example() {
 	// 9 is divisible by 3
 	// Sum of quotients = 12
 }
+
+func ExampleNew() {
+	type ShardID uint32
+
+	d := fastdiv.New(ShardID(7))
+	fmt.Println(d.Div(ShardID(100)), d.Mod(ShardID(100)))
+
+	// Output:
+	// 14 2
+}
@@ -0,0 +1,114 @@
+package fastdiv
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// rhoBatch is the number of steps of Pollard-Brent rho whose differences are
+// multiplied together before a single gcd.
+const rhoBatch = 128
+
+// Factor64 returns the prime factors of n in ascending order, repeated by multiplicity.
+// Factor64(0) and Factor64(1) return nil.
+// The factors below 256 are found by the Divisible checks of a table of pre-computed
+// inverses and divided out exactly by multiplication, and the remaining cofactor
+// is split by Pollard-Brent rho with Montgomery arithmetic.
+func Factor64(n uint64) []uint64 {
+	if n < 2 {
+		return nil
+	}
+	var factors []uint64
+	for _, g := range smallPrimes {
+		for {
+			i := g.primes.FirstDivisor(g.product.Reduce64(n))
+			if i < 0 {
+				break
+			}
+			p := uint64(g.primes.Divisor(i))
+			// p divides n exactly, so the quotient is the product with the inverse of its odd part
+			n = n * g.inv[i] >> uint(bits.TrailingZeros64(p))
+			factors = append(factors, p)
+			if n == 1 {
+				return factors
+			}
+		}
+	}
+	factors = factorRho(n, factors)
+	slices.Sort(factors)
+	return factors
+}
+
+// factorRho appends the prime factors of n > 1 without factors below primeFilterLimit.
+func factorRho(n uint64, factors []uint64) []uint64 {
+	if n < primeFilterLimit*primeFilterLimit || millerRabin(n) {
+		return append(factors, n)
+	}
+	d := pollardBrent(n)
+	factors = factorRho(d, factors)
+	return factorRho(n/d, factors)
+}
+
+// pollardBrent returns a non-trivial factor of the odd composite n.
+// The pseudo-random sequence x^2 + c is computed in Montgomery form,
+// which leaves the gcds unchanged since 2^64 is coprime to n.
+func pollardBrent(n uint64) uint64 {
+	m := NewMontgomery64(n)
+	for c := m.One(); ; c = m.Add(c, m.One()) {
+		f := func(x uint64) uint64 { return m.Add(m.Square(x), c) }
+		y, x, ys := m.ToMont(2), uint64(0), uint64(0)
+		g, q := uint64(1), m.One()
+		for r := 1; g == 1; r *= 2 {
+			x = y
+			for i := 0; i < r; i++ {
+				y = f(y)
+			}
+			for k := 0; k < r && g == 1; k += rhoBatch {
+				ys = y
+				for i := 0; i < min(rhoBatch, r-k); i++ {
+					y = f(y)
+					q = m.Mul(q, absDiff(x, y))
+				}
+				g = gcd(q, n)
+			}
+		}
+		if g == n {
+			// the batch overshot, so repeat its steps one gcd at a time
+			for g = 1; g == 1; {
+				ys = f(ys)
+				g = gcd(absDiff(x, ys), n)
+			}
+		}
+		if g != n {
+			return g
+		}
+	}
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a < b {
+		return b - a
+	}
+	return a - b
+}
+
+// gcd calculates the greatest common divisor of a and b by the binary algorithm,
+// which needs no division. gcd(0, b) is b.
+func gcd(a, b uint64) uint64 {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	shift := bits.TrailingZeros64(a | b)
+	a >>= uint(bits.TrailingZeros64(a))
+	for b != 0 {
+		b >>= uint(bits.TrailingZeros64(b))
+		if a > b {
+			a, b = b, a
+		}
+		b -= a
+	}
+	return a << uint(shift)
+}
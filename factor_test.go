@@ -0,0 +1,101 @@
+package fastdiv
+
+import (
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+var sinkFactors []uint64
+
+// checkFactors reports whether factors are the sorted prime factorization of n.
+func checkFactors(n uint64, factors []uint64) bool {
+	if n < 2 {
+		return factors == nil
+	}
+	if !slices.IsSorted(factors) {
+		return false
+	}
+	prod := new(big.Int).SetUint64(1)
+	for _, p := range factors {
+		if !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+			return false
+		}
+		prod.Mul(prod, new(big.Int).SetUint64(p))
+	}
+	return prod.IsUint64() && prod.Uint64() == n
+}
+
+func TestFactor64(t *testing.T) {
+	check := func(n uint64) bool {
+		return checkFactors(n, Factor64(n))
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	// semiprimes of two random 32-bit primes are the hardest case for rho
+	checkSemiprime := func(a, b uint32) bool {
+		p, q := nextPrime(uint64(a|1<<31)), nextPrime(uint64(b|1<<31))
+		factors := Factor64(p * q)
+		return checkFactors(p*q, factors) && len(factors) == 2
+	}
+	if err := quick.Check(checkSemiprime, &quick.Config{MaxCount: 20}); err != nil {
+		t.Error(err)
+	}
+}
+
+func nextPrime(n uint64) uint64 {
+	for !IsPrime64(n) {
+		n++
+	}
+	return n
+}
+
+func TestFactor64Edges(t *testing.T) {
+	for _, tc := range []struct {
+		n    uint64
+		want []uint64
+	}{
+		{0, nil},
+		{1, nil},
+		{2, []uint64{2}},
+		{1 << 63, slices.Repeat([]uint64{2}, 63)},
+		{255 * 256, []uint64{2, 2, 2, 2, 2, 2, 2, 2, 3, 5, 17}},
+		{257 * 257, []uint64{257, 257}},
+		{561, []uint64{3, 11, 17}},
+		{4294967291 * 4294967279, []uint64{4294967279, 4294967291}},
+		{4294967291 * 4294967291, []uint64{4294967291, 4294967291}},
+		{2097143 * 2097143 * 2097143, []uint64{2097143, 2097143, 2097143}},
+		{3825123056546413051, []uint64{149491, 747451, 34233211}},
+		{math.MaxUint64 - 58, []uint64{math.MaxUint64 - 58}},
+		{math.MaxUint64, []uint64{3, 5, 17, 257, 641, 65537, 6700417}},
+	} {
+		if got := Factor64(tc.n); !slices.Equal(got, tc.want) {
+			t.Errorf("Factor64(%d): got %v, want %v", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestGCD(t *testing.T) {
+	check := func(a, b uint64) bool {
+		want := new(big.Int).GCD(nil, nil, new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+		return gcd(a, b) == want.Uint64()
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkFactor64Semiprime(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkFactors = Factor64(4294967291 * 4294967279)
+	}
+}
+
+func BenchmarkFactor64Smooth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkFactors = Factor64(2 * 3 * 3 * 5 * 7 * 11 * 13 * 17 * 19 * 23 * 29 * 31 * 37 * 41 * 43 * 47)
+	}
+}
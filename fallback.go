@@ -0,0 +1,198 @@
+package fastdiv
+
+// Fallback calculates division by using a pre-computed inverse like Divisor,
+// but substitutes a fixed fallback value for the results of dividing by zero
+// instead of raising a runtime divide-by-zero panic.
+type Fallback[T Integer] struct {
+	div      Divisor[T]
+	zero     bool
+	fallback T
+}
+
+// NewFallback initializes a new pre-computed inverse for any d.
+// If d == 0, Div and Mod and their Floor and Euclid forms return fallback, the DivMod
+// forms return fallback for both the quotient and the remainder and Divisible reports whether n == 0.
+func NewFallback[T Integer](d, fallback T) Fallback[T] {
+	if d == 0 {
+		return Fallback[T]{zero: true, fallback: fallback}
+	}
+	return Fallback[T]{div: New(d), fallback: fallback}
+}
+
+// Div calculates n / d using the pre-computed inverse, or returns the fallback if d == 0.
+func (d Fallback[T]) Div(n T) T {
+	if d.zero {
+		return d.fallback
+	}
+	return d.div.Div(n)
+}
+
+// Mod calculates n % d using the pre-computed inverse, or returns the fallback if d == 0.
+func (d Fallback[T]) Mod(n T) T {
+	if d.zero {
+		return d.fallback
+	}
+	return d.div.Mod(n)
+}
+
+// DivMod calculates n / d and n % d using the pre-computed inverse,
+// or returns the fallback for both if d == 0.
+func (d Fallback[T]) DivMod(n T) (q, r T) {
+	if d.zero {
+		return d.fallback, d.fallback
+	}
+	return d.div.DivMod(n)
+}
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+// Only zero is divisible by a zero divisor.
+func (d Fallback[T]) Divisible(n T) bool {
+	if d.zero {
+		return n == 0
+	}
+	return d.div.Divisible(n)
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse,
+// or returns the fallback if d == 0.
+func (d Fallback[T]) DivRound(n T, mode RoundingMode) T {
+	if d.zero {
+		return d.fallback
+	}
+	return d.div.DivRound(n, mode)
+}
+
+// FloorDiv calculates n / d rounded toward negative infinity using the pre-computed inverse,
+// or returns the fallback if d == 0.
+func (d Fallback[T]) FloorDiv(n T) T {
+	if d.zero {
+		return d.fallback
+	}
+	return d.div.FloorDiv(n)
+}
+
+// FloorMod calculates n mod d with the sign of d using the pre-computed inverse,
+// or returns the fallback if d == 0.
+func (d Fallback[T]) FloorMod(n T) T {
+	if d.zero {
+		return d.fallback
+	}
+	return d.div.FloorMod(n)
+}
+
+// FloorDivMod calculates n / d rounded toward negative infinity and n mod d with the sign of d
+// using the pre-computed inverse, or returns the fallback for both if d == 0.
+func (d Fallback[T]) FloorDivMod(n T) (q, r T) {
+	if d.zero {
+		return d.fallback, d.fallback
+	}
+	return d.div.FloorDivMod(n)
+}
+
+// EuclidDiv calculates the Euclidean quotient of n / d using the pre-computed inverse,
+// or returns the fallback if d == 0.
+func (d Fallback[T]) EuclidDiv(n T) T {
+	if d.zero {
+		return d.fallback
+	}
+	return d.div.EuclidDiv(n)
+}
+
+// EuclidMod calculates the non-negative remainder of n / d using the pre-computed inverse,
+// or returns the fallback if d == 0.
+func (d Fallback[T]) EuclidMod(n T) T {
+	if d.zero {
+		return d.fallback
+	}
+	return d.div.EuclidMod(n)
+}
+
+// EuclidDivMod calculates the Euclidean quotient and the non-negative remainder of n / d
+// using the pre-computed inverse, or returns the fallback for both if d == 0.
+func (d Fallback[T]) EuclidDivMod(n T) (q, r T) {
+	if d.zero {
+		return d.fallback, d.fallback
+	}
+	return d.div.EuclidDivMod(n)
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse,
+// or fills dst with the fallback if d == 0. It panics if len(dst) < len(src).
+func (d Fallback[T]) DivSlice(dst, src []T) {
+	if d.zero {
+		fill(dst[:len(src)], d.fallback)
+		return
+	}
+	d.div.DivSlice(dst, src)
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse,
+// or fills dst with the fallback if d == 0. It panics if len(dst) < len(src).
+func (d Fallback[T]) ModSlice(dst, src []T) {
+	if d.zero {
+		fill(dst[:len(src)], d.fallback)
+		return
+	}
+	d.div.ModSlice(dst, src)
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse, or fills q and r with the fallback if d == 0.
+// It panics if len(q) or len(r) < len(src).
+func (d Fallback[T]) DivModSlice(q, r, src []T) {
+	if d.zero {
+		fill(q[:len(src)], d.fallback)
+		fill(r[:len(src)], d.fallback)
+		return
+	}
+	d.div.DivModSlice(q, r, src)
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// Only zero is divisible by a zero divisor. The bits past len(src) in the last word are cleared.
+// It panics if len(dst) < (len(src)+63)/64.
+func (d Fallback[T]) DivisibleMask(dst []uint64, src []T) {
+	if !d.zero {
+		d.div.DivisibleMask(dst, src)
+		return
+	}
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if n == 0 {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. Only zero is divisible by a zero divisor.
+// The remaining elements of s are unspecified.
+func (d Fallback[T]) FilterDivisible(s []T) []T {
+	if !d.zero {
+		return d.div.FilterDivisible(s)
+	}
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if n == 0 {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// IsZero reports whether the divisor is zero and the fallback is in use.
+func (d Fallback[T]) IsZero() bool {
+	return d.zero
+}
+
+// fill sets every element of s to v.
+func fill[T any](s []T, v T) {
+	for i := range s {
+		s[i] = v
+	}
+}
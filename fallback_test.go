@@ -0,0 +1,110 @@
+package fastdiv
+
+import (
+	"errors"
+	"testing"
+	"testing/quick"
+)
+
+func TestTryNew(t *testing.T) {
+	if _, err := TryNewUint8(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewUint8(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewUint16(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewUint16(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewUint32(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewUint32(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewUint64(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewUint64(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewInt8(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewInt8(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewInt16(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewInt16(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewInt32(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewInt32(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewInt64(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewInt64(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewUint(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewUint(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewInt(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewInt(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewUintptr(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewUintptr(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNew(shardID(0)); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNew(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+
+	checkTryNew := func(x uint32, y int64) bool {
+		if x == 0 || y == 0 {
+			return true
+		}
+		u, err := TryNewUint32(x)
+		if err != nil || u != NewUint32(x) {
+			return false
+		}
+		i, err := TryNewInt64(y)
+		if err != nil || i != NewInt64(y) {
+			return false
+		}
+		d, err := TryNew(y)
+		return err == nil && d == New(y)
+	}
+	if err := quick.Check(checkTryNew, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	const fallback = -1
+	z := NewFallback(0, int32(fallback))
+	if !z.IsZero() {
+		t.Error("IsZero() = false for zero divisor")
+	}
+	for _, n := range []int32{-7, 0, 7} {
+		q, r := z.DivMod(n)
+		if z.Div(n) != fallback || z.Mod(n) != fallback || q != fallback || r != fallback {
+			t.Errorf("%d / 0: got %d, %d, want fallback %d", n, q, r, fallback)
+		}
+		fq, fr := z.FloorDivMod(n)
+		eq, er := z.EuclidDivMod(n)
+		if z.FloorDiv(n) != fallback || z.FloorMod(n) != fallback || fq != fallback || fr != fallback ||
+			z.EuclidDiv(n) != fallback || z.EuclidMod(n) != fallback || eq != fallback || er != fallback {
+			t.Errorf("%d / 0: floor and Euclidean division do not return fallback %d", n, fallback)
+		}
+		if z.Divisible(n) != (n == 0) {
+			t.Errorf("Divisible(%d) = %v for zero divisor", n, z.Divisible(n))
+		}
+	}
+
+	checkFallback := func(x, y int32) bool {
+		d := NewFallback(y, fallback)
+		if y == 0 {
+			return d.IsZero() && d.Div(x) == fallback && d.Mod(x) == fallback
+		}
+		q, r := d.DivMod(x)
+		if !d.IsZero() && d.Div(x) == x/y && d.Mod(x) == x%y && q == x/y && r == x%y &&
+			d.Divisible(x) == (x%y == 0) {
+			c := New(y)
+			fq, fr := d.FloorDivMod(x)
+			cfq, cfr := c.FloorDivMod(x)
+			eq, er := d.EuclidDivMod(x)
+			ceq, cer := c.EuclidDivMod(x)
+			return d.FloorDiv(x) == c.FloorDiv(x) && d.FloorMod(x) == c.FloorMod(x) && fq == cfq && fr == cfr &&
+				d.EuclidDiv(x) == c.EuclidDiv(x) && d.EuclidMod(x) == c.EuclidMod(x) && eq == ceq && er == cer
+		}
+		return false
+	}
+	if err := quick.Check(checkFallback, nil); err != nil {
+		t.Error(err)
+	}
+}
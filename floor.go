@@ -0,0 +1,286 @@
+package fastdiv
+
+// Floored division rounds the quotient toward negative infinity so the
+// remainder takes the sign of the divisor, while Euclidean division always
+// leaves a non-negative remainder.  Both are derived from the truncated
+// DivMod with a branch-free adjustment of at most one in the quotient.
+
+// value returns the signed divisor d.
+func (d Int8) value() int8 {
+	if d.neg {
+		return -int8(d.absd)
+	}
+	return int8(d.absd)
+}
+
+// FloorDiv calculates n / d rounded toward negative infinity using the pre-computed inverse.
+func (d Int8) FloorDiv(n int8) int8 {
+	q, _ := d.FloorDivMod(n)
+	return q
+}
+
+// FloorMod calculates n mod d with the sign of d using the pre-computed inverse.
+func (d Int8) FloorMod(n int8) int8 {
+	r := d.Mod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 7
+	return r + d.value()&mask
+}
+
+// FloorDivMod calculates n / d rounded toward negative infinity and n mod d with the sign of d
+// using the pre-computed inverse.
+func (d Int8) FloorDivMod(n int8) (q, r int8) {
+	q, r = d.DivMod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 7
+	return q + mask, r + d.value()&mask
+}
+
+// EuclidDiv calculates the Euclidean quotient of n / d using the pre-computed inverse.
+func (d Int8) EuclidDiv(n int8) int8 {
+	q, _ := d.EuclidDivMod(n)
+	return q
+}
+
+// EuclidMod calculates the non-negative remainder of n / d using the pre-computed inverse.
+func (d Int8) EuclidMod(n int8) int8 {
+	r := d.Mod(n)
+	return r + int8(d.absd)&(r>>7)
+}
+
+// EuclidDivMod calculates the Euclidean quotient and the non-negative remainder of n / d
+// using the pre-computed inverse.
+func (d Int8) EuclidDivMod(n int8) (q, r int8) {
+	q, r = d.DivMod(n)
+	// for negative r, add |d| to r and subtract the sign of d from q
+	mask := r >> 7
+	var s int8
+	if d.neg {
+		s = -1
+	}
+	return q + (mask ^ s) - s, r + int8(d.absd)&mask
+}
+
+// value returns the signed divisor d.
+func (d Int16) value() int16 {
+	if d.neg {
+		return -int16(d.absd)
+	}
+	return int16(d.absd)
+}
+
+// FloorDiv calculates n / d rounded toward negative infinity using the pre-computed inverse.
+func (d Int16) FloorDiv(n int16) int16 {
+	q, _ := d.FloorDivMod(n)
+	return q
+}
+
+// FloorMod calculates n mod d with the sign of d using the pre-computed inverse.
+func (d Int16) FloorMod(n int16) int16 {
+	r := d.Mod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 15
+	return r + d.value()&mask
+}
+
+// FloorDivMod calculates n / d rounded toward negative infinity and n mod d with the sign of d
+// using the pre-computed inverse.
+func (d Int16) FloorDivMod(n int16) (q, r int16) {
+	q, r = d.DivMod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 15
+	return q + mask, r + d.value()&mask
+}
+
+// EuclidDiv calculates the Euclidean quotient of n / d using the pre-computed inverse.
+func (d Int16) EuclidDiv(n int16) int16 {
+	q, _ := d.EuclidDivMod(n)
+	return q
+}
+
+// EuclidMod calculates the non-negative remainder of n / d using the pre-computed inverse.
+func (d Int16) EuclidMod(n int16) int16 {
+	r := d.Mod(n)
+	return r + int16(d.absd)&(r>>15)
+}
+
+// EuclidDivMod calculates the Euclidean quotient and the non-negative remainder of n / d
+// using the pre-computed inverse.
+func (d Int16) EuclidDivMod(n int16) (q, r int16) {
+	q, r = d.DivMod(n)
+	// for negative r, add |d| to r and subtract the sign of d from q
+	mask := r >> 15
+	var s int16
+	if d.neg {
+		s = -1
+	}
+	return q + (mask ^ s) - s, r + int16(d.absd)&mask
+}
+
+// value returns the signed divisor d.
+func (d Int32) value() int32 {
+	if d.neg {
+		return -int32(d.absd)
+	}
+	return int32(d.absd)
+}
+
+// FloorDiv calculates n / d rounded toward negative infinity using the pre-computed inverse.
+func (d Int32) FloorDiv(n int32) int32 {
+	q, _ := d.FloorDivMod(n)
+	return q
+}
+
+// FloorMod calculates n mod d with the sign of d using the pre-computed inverse.
+func (d Int32) FloorMod(n int32) int32 {
+	r := d.Mod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 31
+	return r + d.value()&mask
+}
+
+// FloorDivMod calculates n / d rounded toward negative infinity and n mod d with the sign of d
+// using the pre-computed inverse.
+func (d Int32) FloorDivMod(n int32) (q, r int32) {
+	q, r = d.DivMod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 31
+	return q + mask, r + d.value()&mask
+}
+
+// EuclidDiv calculates the Euclidean quotient of n / d using the pre-computed inverse.
+func (d Int32) EuclidDiv(n int32) int32 {
+	q, _ := d.EuclidDivMod(n)
+	return q
+}
+
+// EuclidMod calculates the non-negative remainder of n / d using the pre-computed inverse.
+func (d Int32) EuclidMod(n int32) int32 {
+	r := d.Mod(n)
+	return r + int32(d.absd)&(r>>31)
+}
+
+// EuclidDivMod calculates the Euclidean quotient and the non-negative remainder of n / d
+// using the pre-computed inverse.
+func (d Int32) EuclidDivMod(n int32) (q, r int32) {
+	q, r = d.DivMod(n)
+	// for negative r, add |d| to r and subtract the sign of d from q
+	mask := r >> 31
+	var s int32
+	if d.neg {
+		s = -1
+	}
+	return q + (mask ^ s) - s, r + int32(d.absd)&mask
+}
+
+// value returns the signed divisor d.
+func (d Int64) value() int64 {
+	if d.neg {
+		return -int64(d.absd)
+	}
+	return int64(d.absd)
+}
+
+// FloorDiv calculates n / d rounded toward negative infinity using the pre-computed inverse.
+func (d Int64) FloorDiv(n int64) int64 {
+	q, _ := d.FloorDivMod(n)
+	return q
+}
+
+// FloorMod calculates n mod d with the sign of d using the pre-computed inverse.
+func (d Int64) FloorMod(n int64) int64 {
+	r := d.Mod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 63
+	return r + d.value()&mask
+}
+
+// FloorDivMod calculates n / d rounded toward negative infinity and n mod d with the sign of d
+// using the pre-computed inverse.
+func (d Int64) FloorDivMod(n int64) (q, r int64) {
+	q, r = d.DivMod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 63
+	return q + mask, r + d.value()&mask
+}
+
+// EuclidDiv calculates the Euclidean quotient of n / d using the pre-computed inverse.
+func (d Int64) EuclidDiv(n int64) int64 {
+	q, _ := d.EuclidDivMod(n)
+	return q
+}
+
+// EuclidMod calculates the non-negative remainder of n / d using the pre-computed inverse.
+func (d Int64) EuclidMod(n int64) int64 {
+	r := d.Mod(n)
+	return r + int64(d.absd)&(r>>63)
+}
+
+// EuclidDivMod calculates the Euclidean quotient and the non-negative remainder of n / d
+// using the pre-computed inverse.
+func (d Int64) EuclidDivMod(n int64) (q, r int64) {
+	q, r = d.DivMod(n)
+	// for negative r, add |d| to r and subtract the sign of d from q
+	mask := r >> 63
+	var s int64
+	if d.neg {
+		s = -1
+	}
+	return q + (mask ^ s) - s, r + int64(d.absd)&mask
+}
+
+// value returns the signed divisor d.
+func (d Int64GMW) value() int64 {
+	if d.neg {
+		return -int64(d.absd)
+	}
+	return int64(d.absd)
+}
+
+// FloorDiv calculates n / d rounded toward negative infinity using the magic number multiplier.
+func (d Int64GMW) FloorDiv(n int64) int64 {
+	q, _ := d.FloorDivMod(n)
+	return q
+}
+
+// FloorMod calculates n mod d with the sign of d using the magic number multiplier.
+func (d Int64GMW) FloorMod(n int64) int64 {
+	r := d.Mod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 63
+	return r + d.value()&mask
+}
+
+// FloorDivMod calculates n / d rounded toward negative infinity and n mod d with the sign of d
+// using the magic number multiplier.
+func (d Int64GMW) FloorDivMod(n int64) (q, r int64) {
+	q, r = d.DivMod(n)
+	// adjust when r is non-zero and its sign differs from d
+	mask := ((r ^ d.value()) & (r | -r)) >> 63
+	return q + mask, r + d.value()&mask
+}
+
+// EuclidDiv calculates the Euclidean quotient of n / d using the magic number multiplier.
+func (d Int64GMW) EuclidDiv(n int64) int64 {
+	q, _ := d.EuclidDivMod(n)
+	return q
+}
+
+// EuclidMod calculates the non-negative remainder of n / d using the magic number multiplier.
+func (d Int64GMW) EuclidMod(n int64) int64 {
+	r := d.Mod(n)
+	return r + int64(d.absd)&(r>>63)
+}
+
+// EuclidDivMod calculates the Euclidean quotient and the non-negative remainder of n / d
+// using the magic number multiplier.
+func (d Int64GMW) EuclidDivMod(n int64) (q, r int64) {
+	q, r = d.DivMod(n)
+	// for negative r, add |d| to r and subtract the sign of d from q
+	mask := r >> 63
+	var s int64
+	if d.neg {
+		s = -1
+	}
+	return q + (mask ^ s) - s, r + int64(d.absd)&mask
+}
@@ -0,0 +1,208 @@
+package fastdiv
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+// floorDivMod is the reference floored division using native operators.
+func floorDivMod[T Integer](n, d T) (q, r T) {
+	q, r = n/d, n%d
+	if r != 0 && (r < 0) != (d < 0) {
+		q, r = q-1, r+d
+	}
+	return q, r
+}
+
+// euclidDivMod is the reference Euclidean division using native operators.
+func euclidDivMod[T Integer](n, d T) (q, r T) {
+	q, r = n/d, n%d
+	if r < 0 {
+		if d < 0 {
+			q, r = q+1, r-d
+		} else {
+			q, r = q-1, r+d
+		}
+	}
+	return q, r
+}
+
+func TestInt8FloorEuclidExhaustive(t *testing.T) {
+	for y := math.MinInt8; y <= math.MaxInt8; y++ {
+		if y == 0 {
+			continue
+		}
+		d := NewInt8(int8(y))
+		for x := math.MinInt8; x <= math.MaxInt8; x++ {
+			n, y := int8(x), int8(y)
+			fq, fr := floorDivMod(n, y)
+			q, r := d.FloorDivMod(n)
+			if q != fq || r != fr || d.FloorDiv(n) != fq || d.FloorMod(n) != fr {
+				t.Fatalf("floor %d / %d: got %d, %d, want %d, %d", n, y, q, r, fq, fr)
+			}
+			eq, er := euclidDivMod(n, y)
+			q, r = d.EuclidDivMod(n)
+			if q != eq || r != er || d.EuclidDiv(n) != eq || d.EuclidMod(n) != er {
+				t.Fatalf("euclid %d / %d: got %d, %d, want %d, %d", n, y, q, r, eq, er)
+			}
+		}
+	}
+}
+
+func TestInt16FloorEuclid(t *testing.T) {
+	checkInt16FloorEuclid := func(x, y int16) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewInt16(y)
+		fq, fr := floorDivMod(x, y)
+		eq, er := euclidDivMod(x, y)
+		q, r := d.FloorDivMod(x)
+		if q != fq || r != fr || d.FloorDiv(x) != fq || d.FloorMod(x) != fr {
+			return false
+		}
+		q, r = d.EuclidDivMod(x)
+		return q == eq && r == er && d.EuclidDiv(x) == eq && d.EuclidMod(x) == er
+	}
+
+	if err := quick.Check(checkInt16FloorEuclid, nil); err != nil {
+		t.Error(err)
+	}
+	for _, y := range []int16{math.MinInt16, -2, -1, 1, 2, math.MaxInt16} {
+		for _, x := range []int16{math.MinInt16, -3, 0, 3, math.MaxInt16} {
+			if !checkInt16FloorEuclid(x, y) {
+				t.Errorf("%d / %d: floor or Euclidean division mismatch", x, y)
+			}
+		}
+	}
+}
+
+func TestInt32FloorEuclid(t *testing.T) {
+	checkInt32FloorEuclid := func(x, y int32) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewInt32(y)
+		fq, fr := floorDivMod(x, y)
+		eq, er := euclidDivMod(x, y)
+		q, r := d.FloorDivMod(x)
+		if q != fq || r != fr || d.FloorDiv(x) != fq || d.FloorMod(x) != fr {
+			return false
+		}
+		q, r = d.EuclidDivMod(x)
+		return q == eq && r == er && d.EuclidDiv(x) == eq && d.EuclidMod(x) == er
+	}
+
+	if err := quick.Check(checkInt32FloorEuclid, nil); err != nil {
+		t.Error(err)
+	}
+	for _, y := range []int32{math.MinInt32, -2, -1, 1, 2, math.MaxInt32} {
+		for _, x := range []int32{math.MinInt32, -3, 0, 3, math.MaxInt32} {
+			if !checkInt32FloorEuclid(x, y) {
+				t.Errorf("%d / %d: floor or Euclidean division mismatch", x, y)
+			}
+		}
+	}
+}
+
+func TestInt64FloorEuclid(t *testing.T) {
+	checkInt64FloorEuclid := func(x, y int64) bool {
+		if y == 0 {
+			return true
+		}
+		d, g := NewInt64(y), NewInt64GMW(y)
+		fq, fr := floorDivMod(x, y)
+		eq, er := euclidDivMod(x, y)
+		q, r := d.FloorDivMod(x)
+		if q != fq || r != fr || d.FloorDiv(x) != fq || d.FloorMod(x) != fr {
+			return false
+		}
+		q, r = g.FloorDivMod(x)
+		if q != fq || r != fr || g.FloorDiv(x) != fq || g.FloorMod(x) != fr {
+			return false
+		}
+		q, r = g.EuclidDivMod(x)
+		if q != eq || r != er || g.EuclidDiv(x) != eq || g.EuclidMod(x) != er {
+			return false
+		}
+		q, r = d.EuclidDivMod(x)
+		return q == eq && r == er && d.EuclidDiv(x) == eq && d.EuclidMod(x) == er
+	}
+
+	if err := quick.Check(checkInt64FloorEuclid, nil); err != nil {
+		t.Error(err)
+	}
+	for _, y := range []int64{math.MinInt64, -2, -1, 1, 2, math.MaxInt64} {
+		for _, x := range []int64{math.MinInt64, -3, 0, 3, math.MaxInt64} {
+			if !checkInt64FloorEuclid(x, y) {
+				t.Errorf("%d / %d: floor or Euclidean division mismatch", x, y)
+			}
+		}
+	}
+}
+
+func TestIntFloorEuclid(t *testing.T) {
+	checkIntFloorEuclid := func(x, y int) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewInt(y)
+		fq, fr := floorDivMod(x, y)
+		eq, er := euclidDivMod(x, y)
+		q, r := d.FloorDivMod(x)
+		if q != fq || r != fr || d.FloorDiv(x) != fq || d.FloorMod(x) != fr {
+			return false
+		}
+		q, r = d.EuclidDivMod(x)
+		return q == eq && r == er && d.EuclidDiv(x) == eq && d.EuclidMod(x) == er
+	}
+
+	if err := quick.Check(checkIntFloorEuclid, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func testDivisorFloorEuclid[T Integer](t *testing.T) {
+	checkFloorEuclid := func(x, y T) bool {
+		if y == 0 {
+			return true
+		}
+		d := New(y)
+		fq, fr := floorDivMod(x, y)
+		eq, er := euclidDivMod(x, y)
+		q, r := d.FloorDivMod(x)
+		if q != fq || r != fr || d.FloorDiv(x) != fq || d.FloorMod(x) != fr {
+			return false
+		}
+		q, r = d.EuclidDivMod(x)
+		return q == eq && r == er && d.EuclidDiv(x) == eq && d.EuclidMod(x) == er
+	}
+	if err := quick.Check(checkFloorEuclid, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDivisorFloorEuclid(t *testing.T) {
+	t.Run("uint8", testDivisorFloorEuclid[uint8])
+	t.Run("uint64", testDivisorFloorEuclid[uint64])
+	t.Run("int8", testDivisorFloorEuclid[int8])
+	t.Run("int16", testDivisorFloorEuclid[int16])
+	t.Run("int32", testDivisorFloorEuclid[int32])
+	t.Run("int64", testDivisorFloorEuclid[int64])
+	t.Run("int", testDivisorFloorEuclid[int])
+}
+
+func BenchmarkInt32FloorDiv(b *testing.B) {
+	d := NewInt32(varInt32)
+	for i := 0; i < b.N; i++ {
+		sinkInt32 = d.FloorDiv(sinkInt32 - int32(i))
+	}
+}
+
+func BenchmarkInt32EuclidMod(b *testing.B) {
+	d := NewInt32(varInt32)
+	for i := 0; i < b.N; i++ {
+		sinkInt32 = d.EuclidMod(sinkInt32 - int32(i))
+	}
+}
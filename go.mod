@@ -0,0 +1,3 @@
+module github.com/bmkessler/fastdiv
+
+go 1.24
@@ -0,0 +1,113 @@
+package fastdiv
+
+// Int calculates division by using a pre-computed inverse.
+// It uses the algorithm of Int64 on 64-bit platforms and of Int32 on 32-bit platforms.
+type Int struct {
+	w intWord
+}
+
+// NewInt initializes a new pre-computed inverse for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+func NewInt(d int) Int {
+	return Int{w: newIntWord(word(d))}
+}
+
+// TryNewInt initializes a new pre-computed inverse like NewInt,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+// Every non-zero int is supported, so ErrZeroDivisor is the only error.
+func TryNewInt(d int) (Int, error) {
+	if d == 0 {
+		return Int{}, ErrZeroDivisor
+	}
+	return NewInt(d), nil
+}
+
+// Div calculates n / d using the pre-computed inverse.
+func (d Int) Div(n int) int {
+	return int(d.w.Div(word(n)))
+}
+
+// Mod calculates n % d using the pre-computed inverse.
+func (d Int) Mod(n int) int {
+	return int(d.w.Mod(word(n)))
+}
+
+// DivMod calculates n / d and n % d using the pre-computed inverse.
+func (d Int) DivMod(n int) (q, r int) {
+	div, mod := d.w.DivMod(word(n))
+	return int(div), int(mod)
+}
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Int) Divisible(n int) bool {
+	return d.w.Divisible(word(n))
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Int) DivRound(n int, mode RoundingMode) int {
+	return int(d.w.DivRound(word(n), mode))
+}
+
+// FloorDiv calculates n / d rounded toward negative infinity using the pre-computed inverse.
+func (d Int) FloorDiv(n int) int {
+	return int(d.w.FloorDiv(word(n)))
+}
+
+// FloorMod calculates n mod d with the sign of d using the pre-computed inverse.
+func (d Int) FloorMod(n int) int {
+	return int(d.w.FloorMod(word(n)))
+}
+
+// FloorDivMod calculates n / d rounded toward negative infinity and n mod d with the sign of d
+// using the pre-computed inverse.
+func (d Int) FloorDivMod(n int) (q, r int) {
+	div, mod := d.w.FloorDivMod(word(n))
+	return int(div), int(mod)
+}
+
+// EuclidDiv calculates the Euclidean quotient of n / d using the pre-computed inverse.
+func (d Int) EuclidDiv(n int) int {
+	return int(d.w.EuclidDiv(word(n)))
+}
+
+// EuclidMod calculates the non-negative remainder of n / d using the pre-computed inverse.
+func (d Int) EuclidMod(n int) int {
+	return int(d.w.EuclidMod(word(n)))
+}
+
+// EuclidDivMod calculates the Euclidean quotient and the non-negative remainder of n / d
+// using the pre-computed inverse.
+func (d Int) EuclidDivMod(n int) (q, r int) {
+	div, mod := d.w.EuclidDivMod(word(n))
+	return int(div), int(mod)
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int) DivSlice(dst, src []int) {
+	d.w.DivSlice(castSlice[word](dst), castSlice[word](src))
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int) ModSlice(dst, src []int) {
+	d.w.ModSlice(castSlice[word](dst), castSlice[word](src))
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Int) DivModSlice(q, r, src []int) {
+	d.w.DivModSlice(castSlice[word](q), castSlice[word](r), castSlice[word](src))
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Int) DivisibleMask(dst []uint64, src []int) {
+	d.w.DivisibleMask(dst, castSlice[word](src))
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Int) FilterDivisible(s []int) []int {
+	return s[:len(d.w.FilterDivisible(castSlice[word](s)))]
+}
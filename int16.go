@@ -4,40 +4,60 @@ import "math/bits"
 
 // Int16 calculates division by using a pre-computed inverse.
 type Int16 struct {
-	absd uint32
-	m    uint32
-	neg  bool
+	absd  uint32
+	m     uint32
+	neg   bool
+	shift uint8 // log2(absd) if pow2
+	pow2  bool
 }
 
 // NewInt16 initializes a new pre-computed inverse for d != 0.
 // If d == 0, a runtime divide-by-zero panic is raised.
+// Divisors whose absolute value is a power of two, including 1, divide with a shift and a mask.
 func NewInt16(d int16) Int16 {
 	var neg bool
 	if d < 0 {
 		neg = true
 		d = -d
 	}
-	absd := uint32(d)
+	absd := uint32(uint16(d))
 	m := ^uint32(0)/absd + 1
-	if absd&(absd-1) == 0 {
+	pow2 := absd&(absd-1) == 0
+	if pow2 && absd > 1 {
 		m++
 	}
 	return Int16{
-		absd: absd,
-		m:    m,
-		neg:  neg,
+		absd:  absd,
+		m:     m,
+		neg:   neg,
+		shift: uint8(bits.TrailingZeros16(uint16(absd))),
+		pow2:  pow2,
 	}
 }
 
+// TryNewInt16 initializes a new pre-computed inverse like NewInt16,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+// Every non-zero int16 is supported, so ErrZeroDivisor is the only error.
+func TryNewInt16(d int16) (Int16, error) {
+	if d == 0 {
+		return Int16{}, ErrZeroDivisor
+	}
+	return NewInt16(d), nil
+}
+
 // Div calculates n / d using the pre-computed inverse.
-// Note, must have d != 1, -1, 0, or math.MinInt16
 func (d Int16) Div(n int16) int16 {
 	neg := d.neg
 	if n < 0 {
 		n = -n
 		neg = !neg
 	}
-	div, _ := bits.Mul32(d.m, uint32(n))
+	div := uint32(uint16(n))
+	if d.pow2 {
+		div >>= d.shift & 15
+	} else {
+		div, _ = bits.Mul32(d.m, div)
+	}
 	if neg {
 		return -int16(div)
 	}
@@ -46,23 +66,31 @@ func (d Int16) Div(n int16) int16 {
 
 // Mod calculates n % d using the pre-computed inverse.
 func (d Int16) Mod(n int16) int16 {
+	if d.pow2 {
+		mask := int16(d.absd - 1)
+		bias := n >> 15 & mask
+		return (n+bias)&mask - bias
+	}
 	fraction := d.m * uint32(n)
 	mod, _ := bits.Mul32(fraction, d.absd)
 	return int16(mod) - (int16(d.absd)-1)&(n>>15)
 }
 
 // DivMod calculates n / d and n % d using the pre-computed inverse.
-// Note, must have d != 1, -1, 0, or math.MinInt16
 func (d Int16) DivMod(n int16) (q, r int16) {
 	var neg bool
 	if n < 0 {
 		n = -n
 		neg = !neg
 	}
-	div, fraction := bits.Mul32(d.m, uint32(n))
-	q = int16(div)
-	mod, _ := bits.Mul32(fraction, d.absd)
-	r = int16(mod)
+	if d.pow2 {
+		q, r = int16(uint16(n)>>(d.shift&15)), n&int16(d.absd-1)
+	} else {
+		div, fraction := bits.Mul32(d.m, uint32(uint16(n)))
+		q = int16(div)
+		mod, _ := bits.Mul32(fraction, d.absd)
+		r = int16(mod)
+	}
 	if neg {
 		q = -q
 		r = -r
@@ -72,3 +100,12 @@ func (d Int16) DivMod(n int16) (q, r int16) {
 	}
 	return q, r
 }
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Int16) Divisible(n int16) bool {
+	absn := uint16(n)
+	if n < 0 {
+		absn = -absn
+	}
+	return d.m*uint32(absn) <= d.m-1
+}
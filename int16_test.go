@@ -15,7 +15,7 @@ const constInt16 int16 = 12345
 
 func TestInt16Div(t *testing.T) {
 	checkInt16Div := func(x, y int16) bool {
-		if y == 0 || y == 1 || y == -1 || y == math.MinInt16 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt16(y)
@@ -29,7 +29,7 @@ func TestInt16Div(t *testing.T) {
 
 func TestInt16Mod(t *testing.T) {
 	checkInt16Mod := func(x, y int16) bool {
-		if y == 0 || y == -1 || y == math.MinInt16 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt16(y)
@@ -43,7 +43,7 @@ func TestInt16Mod(t *testing.T) {
 
 func TestInt16DivMod(t *testing.T) {
 	checkInt16DivMod := func(x, y int16) bool {
-		if y == 0 || y == 1 || y == -1 || y == math.MinInt16 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt16(y)
@@ -56,6 +56,64 @@ func TestInt16DivMod(t *testing.T) {
 	}
 }
 
+func TestInt16Divisible(t *testing.T) {
+	checkInt16Divisible := func(x, y int16) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewInt16(y)
+		if ((x % y) == 0) != d.Divisible(x) {
+			return false
+		}
+		x, y = int16(int8(x)), int16(int8(y))
+		if y == 0 {
+			return true
+		}
+		d = NewInt16(y)
+		return d.Divisible(x * y)
+	}
+
+	if err := quick.Check(checkInt16Divisible, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt16Exhaustive(t *testing.T) {
+	divisors := make([]int16, 0, math.MaxUint16)
+	for y := math.MinInt16; y <= math.MaxInt16; y++ {
+		if y == 0 || testing.Short() && y > math.MinInt16+256 && y < math.MaxInt16-256 && (y < -256 || y > 256) && y&(y-1) != 0 {
+			continue
+		}
+		divisors = append(divisors, int16(y))
+	}
+
+	for _, y := range divisors {
+		d := NewInt16(y)
+		absy := int32(y)
+		if absy < 0 {
+			absy = -absy
+		}
+		// walk away from zero in both directions tracking |n| / |y| and |n| % |y|
+		for _, sign := range []int32{1, -1} {
+			var q, r int32
+			for x := int32(0); x >= math.MinInt16 && x <= math.MaxInt16; x += sign {
+				n := int16(x)
+				wantq, wantr := int16(sign*q), int16(sign*r)
+				if y < 0 {
+					wantq = -wantq
+				}
+				dq, dr := d.DivMod(n)
+				if d.Div(n) != wantq || d.Mod(n) != wantr || dq != wantq || dr != wantr || d.Divisible(n) != (r == 0) {
+					t.Fatalf("%d / %d: got %d, %d, want %d, %d", n, y, dq, dr, wantq, wantr)
+				}
+				if r++; r == absy {
+					q, r = q+1, 0
+				}
+			}
+		}
+	}
+}
+
 func TestInt16DivSpeed(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping speed test in short mode")
@@ -161,3 +219,34 @@ func BenchmarkInt16Mod(b *testing.B) {
 		sinkInt16 = d.Mod(sinkInt16)
 	}
 }
+
+func BenchmarkInt16DivisibleVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (int16(i) % varInt16) == 0 {
+			sinkInt16 = 2.0
+		} else {
+			sinkInt16 = 1.0
+		}
+	}
+}
+
+func BenchmarkInt16DivisibleConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (int16(i) % constInt16) == 0 {
+			sinkInt16 = 2.0
+		} else {
+			sinkInt16 = 1.0
+		}
+	}
+}
+
+func BenchmarkInt16Divisible(b *testing.B) {
+	d := NewInt16(varInt16)
+	for i := 0; i < b.N; i++ {
+		if d.Divisible(int16(i)) {
+			sinkInt16 = 2.0
+		} else {
+			sinkInt16 = 1.0
+		}
+	}
+}
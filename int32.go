@@ -4,40 +4,60 @@ import "math/bits"
 
 // Int32 calculates division by using a pre-computed inverse.
 type Int32 struct {
-	absd uint64
-	m    uint64
-	neg  bool
+	absd  uint64
+	m     uint64
+	neg   bool
+	shift uint8 // log2(absd) if pow2
+	pow2  bool
 }
 
 // NewInt32 initializes a new pre-computed inverse for d != 0.
 // If d == 0, a runtime divide-by-zero panic is raised.
+// Divisors whose absolute value is a power of two, including 1, divide with a shift and a mask.
 func NewInt32(d int32) Int32 {
 	var neg bool
 	if d < 0 {
 		neg = true
 		d = -d
 	}
-	absd := uint64(d)
+	absd := uint64(uint32(d))
 	m := ^uint64(0)/absd + 1
-	if absd&(absd-1) == 0 {
+	pow2 := absd&(absd-1) == 0
+	if pow2 && absd > 1 {
 		m++
 	}
 	return Int32{
-		absd: absd,
-		m:    m,
-		neg:  neg,
+		absd:  absd,
+		m:     m,
+		neg:   neg,
+		shift: uint8(bits.TrailingZeros32(uint32(absd))),
+		pow2:  pow2,
 	}
 }
 
+// TryNewInt32 initializes a new pre-computed inverse like NewInt32,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+// Every non-zero int32 is supported, so ErrZeroDivisor is the only error.
+func TryNewInt32(d int32) (Int32, error) {
+	if d == 0 {
+		return Int32{}, ErrZeroDivisor
+	}
+	return NewInt32(d), nil
+}
+
 // Div calculates n / d using the pre-computed inverse.
-// Note, must have d != 1, -1, 0, or math.MinInt32
 func (d Int32) Div(n int32) int32 {
 	neg := d.neg
 	if n < 0 {
 		n = -n
 		neg = !neg
 	}
-	div, _ := bits.Mul64(d.m, uint64(n))
+	div := uint64(uint32(n))
+	if d.pow2 {
+		div >>= d.shift & 31
+	} else {
+		div, _ = bits.Mul64(d.m, div)
+	}
 	if neg {
 		return -int32(div)
 	}
@@ -46,23 +66,31 @@ func (d Int32) Div(n int32) int32 {
 
 // Mod calculates n % d using the pre-computed inverse.
 func (d Int32) Mod(n int32) int32 {
+	if d.pow2 {
+		mask := int32(d.absd - 1)
+		bias := n >> 31 & mask
+		return (n+bias)&mask - bias
+	}
 	fraction := d.m * uint64(n)
 	mod, _ := bits.Mul64(fraction, d.absd)
 	return int32(mod) - (int32(d.absd)-1)&(n>>31)
 }
 
 // DivMod calculates n / d and n % d using the pre-computed inverse.
-// Note, must have d != 1, -1, 0, or math.MinInt32
 func (d Int32) DivMod(n int32) (q, r int32) {
 	var neg bool
 	if n < 0 {
 		n = -n
 		neg = !neg
 	}
-	div, fraction := bits.Mul64(d.m, uint64(n))
-	q = int32(div)
-	mod, _ := bits.Mul64(fraction, d.absd)
-	r = int32(mod)
+	if d.pow2 {
+		q, r = int32(uint32(n)>>(d.shift&31)), n&int32(d.absd-1)
+	} else {
+		div, fraction := bits.Mul64(d.m, uint64(uint32(n)))
+		q = int32(div)
+		mod, _ := bits.Mul64(fraction, d.absd)
+		r = int32(mod)
+	}
 	if neg {
 		q = -q
 		r = -r
@@ -72,3 +100,12 @@ func (d Int32) DivMod(n int32) (q, r int32) {
 	}
 	return q, r
 }
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Int32) Divisible(n int32) bool {
+	absn := uint32(n)
+	if n < 0 {
+		absn = -absn
+	}
+	return d.m*uint64(absn) <= d.m-1
+}
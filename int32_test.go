@@ -15,7 +15,7 @@ const constInt32 int32 = 123456789
 
 func TestInt32Div(t *testing.T) {
 	checkInt32Div := func(x, y int32) bool {
-		if y == 0 || y == 1 || y == -1 || y == math.MinInt32 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt32(y)
@@ -29,7 +29,7 @@ func TestInt32Div(t *testing.T) {
 
 func TestInt32Mod(t *testing.T) {
 	checkInt32Mod := func(x, y int32) bool {
-		if y == 0 || y == -1 || y == math.MinInt32 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt32(y)
@@ -43,7 +43,7 @@ func TestInt32Mod(t *testing.T) {
 
 func TestInt32DivMod(t *testing.T) {
 	checkInt32DivMod := func(x, y int32) bool {
-		if y == 0 || y == 1 || y == -1 || y == math.MinInt32 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt32(y)
@@ -56,6 +56,47 @@ func TestInt32DivMod(t *testing.T) {
 	}
 }
 
+func TestInt32Divisible(t *testing.T) {
+	checkInt32Divisible := func(x, y int32) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewInt32(y)
+		if ((x % y) == 0) != d.Divisible(x) {
+			return false
+		}
+		x, y = int32(int16(x)), int32(int16(y))
+		if y == 0 {
+			return true
+		}
+		d = NewInt32(y)
+		return d.Divisible(x * y)
+	}
+
+	if err := quick.Check(checkInt32Divisible, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt32Edge(t *testing.T) {
+	edges := []int32{
+		math.MinInt32, math.MinInt32 + 1, math.MinInt32 / 2, -1 << 16, -7, -3, -2, -1,
+		0, 1, 2, 3, 7, 1 << 16, math.MaxInt32 / 2, math.MaxInt32 - 1, math.MaxInt32,
+	}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d := NewInt32(y)
+		for _, x := range edges {
+			q, r := d.DivMod(x)
+			if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, x/y, x%y)
+			}
+		}
+	}
+}
+
 func TestInt32DivSpeed(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping speed test in short mode")
@@ -161,3 +202,34 @@ func BenchmarkInt32Mod(b *testing.B) {
 		sinkInt32 = d.Mod(sinkInt32)
 	}
 }
+
+func BenchmarkInt32DivisibleVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (int32(i) % varInt32) == 0 {
+			sinkInt32 = 2.0
+		} else {
+			sinkInt32 = 1.0
+		}
+	}
+}
+
+func BenchmarkInt32DivisibleConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (int32(i) % constInt32) == 0 {
+			sinkInt32 = 2.0
+		} else {
+			sinkInt32 = 1.0
+		}
+	}
+}
+
+func BenchmarkInt32Divisible(b *testing.B) {
+	d := NewInt32(varInt32)
+	for i := 0; i < b.N; i++ {
+		if d.Divisible(int32(i)) {
+			sinkInt32 = 2.0
+		} else {
+			sinkInt32 = 1.0
+		}
+	}
+}
@@ -7,9 +7,13 @@ type Int64 struct {
 	absd   uint64
 	hi, lo uint64
 	neg    bool
+	shift  uint8 // log2(absd) if pow2
+	pow2   bool
 }
 
-// NewInt64 initializes a new pre-computed inverse.
+// NewInt64 initializes a new pre-computed inverse for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+// Divisors whose absolute value is a power of two, including 1, divide with a shift and a mask.
 func NewInt64(d int64) Int64 {
 	var neg bool
 	if d < 0 {
@@ -22,21 +26,33 @@ func NewInt64(d int64) Int64 {
 	lo, _ := bits.Div64(r, ^uint64(0), absd)
 
 	var c uint64 = 1
-	if absd&(absd-1) == 0 {
+	pow2 := absd&(absd-1) == 0
+	if pow2 && absd > 1 {
 		c++
 	}
 	lo, c = bits.Add64(lo, c, 0)
 	hi, _ = bits.Add64(hi, 0, c)
 	return Int64{
-		absd: absd,
-		hi:   hi,
-		lo:   lo,
-		neg:  neg,
+		absd:  absd,
+		hi:    hi,
+		lo:    lo,
+		neg:   neg,
+		shift: uint8(bits.TrailingZeros64(absd)),
+		pow2:  pow2,
 	}
 }
 
+// TryNewInt64 initializes a new pre-computed inverse like NewInt64,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+// Every non-zero int64 is supported, so ErrZeroDivisor is the only error.
+func TryNewInt64(d int64) (Int64, error) {
+	if d == 0 {
+		return Int64{}, ErrZeroDivisor
+	}
+	return NewInt64(d), nil
+}
+
 // Div calculates n / d using the pre-computed inverse.
-// Note, must have d != 1, -1, 0, or math.MinInt32
 func (d Int64) Div(n int64) int64 {
 	neg := d.neg
 	if n < 0 {
@@ -44,11 +60,15 @@ func (d Int64) Div(n int64) int64 {
 		neg = !neg
 	}
 
-	divlo1, _ := bits.Mul64(d.lo, uint64(n))
-	div, divlo2 := bits.Mul64(d.hi, uint64(n))
-	var c uint64
-	_, c = bits.Add64(divlo1, divlo2, 0)
-	div, _ = bits.Add64(div, 0, c)
+	// the shift is the quotient for powers of two, which keeps Div cheap enough to inline
+	div := uint64(n) >> (d.shift & 63)
+	if !d.pow2 {
+		divlo1, _ := bits.Mul64(d.lo, uint64(n))
+		var divlo2 uint64
+		div, divlo2 = bits.Mul64(d.hi, uint64(n))
+		_, c := bits.Add64(divlo1, divlo2, 0)
+		div += c
+	}
 
 	if neg {
 		return -int64(div)
@@ -58,6 +78,11 @@ func (d Int64) Div(n int64) int64 {
 
 // Mod calculates n % d using the pre-computed inverse.
 func (d Int64) Mod(n int64) int64 {
+	if d.pow2 {
+		mask := int64(d.absd - 1)
+		bias := n >> 63 & mask
+		return (n+bias)&mask - bias
+	}
 	var neg bool
 	if n < 0 {
 		n = -n
@@ -77,7 +102,6 @@ func (d Int64) Mod(n int64) int64 {
 }
 
 // DivMod calculates n / d and n % d using the pre-computed inverse.
-// Note, must have d != 1, -1, 0, or math.MinInt32
 func (d Int64) DivMod(n int64) (q, r int64) {
 	var neg bool
 	if n < 0 {
@@ -85,19 +109,23 @@ func (d Int64) DivMod(n int64) (q, r int64) {
 		neg = true
 	}
 
-	divlo1, lo := bits.Mul64(d.lo, uint64(n))
-	div, divlo2 := bits.Mul64(d.hi, uint64(n))
+	if d.pow2 {
+		q, r = int64(uint64(n)>>(d.shift&63)), n&int64(d.absd-1)
+	} else {
+		divlo1, lo := bits.Mul64(d.lo, uint64(n))
+		div, divlo2 := bits.Mul64(d.hi, uint64(n))
 
-	hi, c := bits.Add64(divlo1, divlo2, 0)
-	div, _ = bits.Add64(div, 0, c)
-	q = int64(div)
+		hi, c := bits.Add64(divlo1, divlo2, 0)
+		div, _ = bits.Add64(div, 0, c)
+		q = int64(div)
 
-	modlo1, _ := bits.Mul64(lo, d.absd)
-	mod, modlo2 := bits.Mul64(hi, d.absd)
+		modlo1, _ := bits.Mul64(lo, d.absd)
+		mod, modlo2 := bits.Mul64(hi, d.absd)
 
-	_, c = bits.Add64(modlo1, modlo2, 0)
-	mod, _ = bits.Add64(mod, 0, c)
-	r = int64(mod)
+		_, c = bits.Add64(modlo1, modlo2, 0)
+		mod, _ = bits.Add64(mod, 0, c)
+		r = int64(mod)
+	}
 
 	if neg {
 		q = -q
@@ -109,3 +137,17 @@ func (d Int64) DivMod(n int64) (q, r int64) {
 
 	return q, r
 }
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Int64) Divisible(n int64) bool {
+	absn := uint64(n)
+	if n < 0 {
+		absn = -absn
+	}
+	var hicheck, locheck, b uint64
+	locheck, b = bits.Sub64(d.lo, 1, b)
+	hicheck, _ = bits.Sub64(d.hi, 0, b)
+	hi, lo := bits.Mul64(d.lo, absn)
+	hi += d.hi * absn
+	return (hi < hicheck) || ((hi == hicheck) && (lo <= locheck))
+}
@@ -17,7 +17,7 @@ const (
 
 func TestInt64Div(t *testing.T) {
 	checkInt64Div := func(x, y int64) bool {
-		if y == 0 || y == 1 || y == -1 || y == math.MinInt64 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt64(y)
@@ -31,7 +31,7 @@ func TestInt64Div(t *testing.T) {
 
 func TestInt64Mod(t *testing.T) {
 	checkInt64Mod := func(x, y int64) bool {
-		if y == 0 || y == -1 || y == math.MinInt64 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt64(y)
@@ -45,7 +45,7 @@ func TestInt64Mod(t *testing.T) {
 
 func TestInt64DivMod(t *testing.T) {
 	checkInt64DivMod := func(x, y int64) bool {
-		if y == 0 || y == 1 || y == -1 || y == math.MinInt64 {
+		if y == 0 {
 			return true
 		}
 		d := NewInt64(y)
@@ -58,6 +58,47 @@ func TestInt64DivMod(t *testing.T) {
 	}
 }
 
+func TestInt64Divisible(t *testing.T) {
+	checkInt64Divisible := func(x, y int64) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewInt64(y)
+		if ((x % y) == 0) != d.Divisible(x) {
+			return false
+		}
+		x, y = int64(int32(x)), int64(int32(y))
+		if y == 0 {
+			return true
+		}
+		d = NewInt64(y)
+		return d.Divisible(x * y)
+	}
+
+	if err := quick.Check(checkInt64Divisible, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt64Edge(t *testing.T) {
+	edges := []int64{
+		math.MinInt64, math.MinInt64 + 1, math.MinInt64 / 2, -1 << 32, -7, -3, -2, -1,
+		0, 1, 2, 3, 7, 1 << 32, math.MaxInt64 / 2, math.MaxInt64 - 1, math.MaxInt64,
+	}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d := NewInt64(y)
+		for _, x := range edges {
+			q, r := d.DivMod(x)
+			if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, x/y, x%y)
+			}
+		}
+	}
+}
+
 func TestInt64DivSpeed(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping speed test in short mode")
@@ -163,3 +204,34 @@ func BenchmarkInt64Mod(b *testing.B) {
 		sinkInt64 = d.Mod(sinkInt64)
 	}
 }
+
+func BenchmarkInt64DivisibleVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (int64(i) % varInt64) == 0 {
+			sinkInt64 = 2.0
+		} else {
+			sinkInt64 = 1.0
+		}
+	}
+}
+
+func BenchmarkInt64DivisibleConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (int64(i) % constInt64) == 0 {
+			sinkInt64 = 2.0
+		} else {
+			sinkInt64 = 1.0
+		}
+	}
+}
+
+func BenchmarkInt64Divisible(b *testing.B) {
+	d := NewInt64(varInt64)
+	for i := 0; i < b.N; i++ {
+		if d.Divisible(int64(i)) {
+			sinkInt64 = 2.0
+		} else {
+			sinkInt64 = 1.0
+		}
+	}
+}
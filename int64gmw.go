@@ -0,0 +1,110 @@
+package fastdiv
+
+import "math/bits"
+
+// Int64GMW calculates division by using the magic number multiplier of
+// Granlund, Montgomery and Warren on the magnitudes of the operands.
+// It produces the same results as Int64.
+type Int64GMW struct {
+	absd uint64
+	m    uint64 // magic multiplier
+	inv  uint64 // inverse of the odd part of absd modulo 2^64
+	lim  uint64 // largest quotient of a uint64 by absd
+	sh   uint8
+	tz   uint8 // trailing zeros of absd
+	neg  bool
+	pow2 bool
+}
+
+// NewInt64GMW initializes a new magic number multiplier for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+// The magnitude of a dividend is at most 2^63, so unlike Uint64GMW the multiplier
+// always fits in 64 bits and no add indicator is needed. Divisors whose absolute value
+// is a power of two, including 1, divide with a shift and a mask.
+func NewInt64GMW(d int64) Int64GMW {
+	absd := uint64(d)
+	if d < 0 {
+		absd = -absd
+	}
+	inv, tz := oddInverse64(absd)
+	i := Int64GMW{absd: absd, inv: inv, lim: ^uint64(0) / absd, tz: tz, neg: d < 0}
+	if absd&(absd-1) == 0 {
+		i.pow2 = true
+		return i
+	}
+	// m = ceil(2^(63+l) / |d|) with l = ceil(log2(|d|)) is below 2^64 and its error
+	// m * |d| - 2^(63+l) < 2^l keeps the quotients of all |n| <= 2^63 exact.
+	l := bits.Len64(absd - 1)
+	m, rem := bits.Div64(1<<(l-1), 0, absd)
+	if rem != 0 {
+		m++
+	}
+	i.m = m
+	i.sh = uint8(l - 1)
+	return i
+}
+
+// TryNewInt64GMW initializes a new magic number multiplier like NewInt64GMW,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+// Every non-zero int64 is supported, so ErrZeroDivisor is the only error.
+func TryNewInt64GMW(d int64) (Int64GMW, error) {
+	if d == 0 {
+		return Int64GMW{}, ErrZeroDivisor
+	}
+	return NewInt64GMW(d), nil
+}
+
+// Div calculates n / d using the magic number multiplier.
+func (d Int64GMW) Div(n int64) int64 {
+	neg := d.neg
+	if n < 0 {
+		n = -n
+		neg = !neg
+	}
+	div := uint64(n)
+	if d.pow2 {
+		div >>= d.tz & 63
+	} else {
+		div, _ = bits.Mul64(d.m, div)
+		div >>= d.sh & 63
+	}
+	if neg {
+		return -int64(div)
+	}
+	return int64(div)
+}
+
+// Mod calculates n % d using the magic number multiplier.
+func (d Int64GMW) Mod(n int64) int64 {
+	if d.pow2 {
+		mask := int64(d.absd - 1)
+		bias := n >> 63 & mask
+		return (n+bias)&mask - bias
+	}
+	var neg bool
+	if n < 0 {
+		n = -n
+		neg = true
+	}
+	div, _ := bits.Mul64(d.m, uint64(n))
+	mod := uint64(n) - div>>(d.sh&63)*d.absd
+	if neg {
+		return -int64(mod)
+	}
+	return int64(mod)
+}
+
+// DivMod calculates n / d and n % d using the magic number multiplier.
+func (d Int64GMW) DivMod(n int64) (q, r int64) {
+	q = d.Div(n)
+	return q, n - q*d.value()
+}
+
+// Divisible determines whether n is exactly divisible by d using the inverse of its odd part.
+func (d Int64GMW) Divisible(n int64) bool {
+	absn := uint64(n)
+	if n < 0 {
+		absn = -absn
+	}
+	return bits.RotateLeft64(absn*d.inv, -int(d.tz)) <= d.lim
+}
@@ -0,0 +1,74 @@
+package fastdiv
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+func TestInt64GMW(t *testing.T) {
+	checkInt64GMW := func(x, y int64) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewInt64GMW(y)
+		q, r := d.DivMod(x)
+		return d.Div(x) == x/y && d.Mod(x) == x%y && q == x/y && r == x%y &&
+			d.Divisible(x) == (x%y == 0) && d.Divisible(x/y*y)
+	}
+	if err := quick.Check(checkInt64GMW, nil); err != nil {
+		t.Error(err)
+	}
+
+	checkSmall := func(x int64, y int16) bool {
+		return checkInt64GMW(x, int64(y)) && checkInt64GMW(x>>(uint16(y)%64), int64(y))
+	}
+	if err := quick.Check(checkSmall, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt64GMWEdge(t *testing.T) {
+	edges := []int64{
+		math.MinInt64, math.MinInt64 + 1, math.MinInt64 / 2, -1 << 32, -7, -3, -2, -1,
+		0, 1, 2, 3, 7, 1 << 32, math.MaxInt64 / 2, math.MaxInt64 - 1, math.MaxInt64,
+	}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d, c := NewInt64GMW(y), NewInt64(y)
+		for _, x := range edges {
+			q, r := d.DivMod(x)
+			cq, cr := c.DivMod(x)
+			if d.Div(x) != c.Div(x) || d.Mod(x) != c.Mod(x) || q != cq || r != cr || d.Divisible(x) != c.Divisible(x) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, cq, cr)
+			}
+		}
+	}
+}
+
+func BenchmarkInt64GMWDiv(b *testing.B) {
+	d := NewInt64GMW(varInt64)
+	for i := 0; i < b.N; i++ {
+		sinkInt64 = d.Div(sinkInt64)
+	}
+}
+
+func BenchmarkInt64GMWMod(b *testing.B) {
+	d := NewInt64GMW(varInt64)
+	for i := 0; i < b.N; i++ {
+		sinkInt64 = d.Mod(sinkInt64)
+	}
+}
+
+func BenchmarkInt64GMWDivisible(b *testing.B) {
+	d := NewInt64GMW(varInt64)
+	for i := 0; i < b.N; i++ {
+		if d.Divisible(int64(i)) {
+			sinkInt64 = 2.0
+		} else {
+			sinkInt64 = 1.0
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package fastdiv
+
+import "math/bits"
+
+// Int8 calculates division by using a pre-computed inverse.
+type Int8 struct {
+	absd  uint16
+	m     uint16
+	neg   bool
+	shift uint8 // log2(absd) if pow2
+	pow2  bool
+}
+
+// NewInt8 initializes a new pre-computed inverse for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+// Divisors whose absolute value is a power of two, including 1, divide with a shift and a mask.
+func NewInt8(d int8) Int8 {
+	var neg bool
+	if d < 0 {
+		neg = true
+		d = -d
+	}
+	absd := uint16(uint8(d))
+	m := ^uint16(0)/absd + 1
+	pow2 := absd&(absd-1) == 0
+	if pow2 && absd > 1 {
+		m++
+	}
+	return Int8{
+		absd:  absd,
+		m:     m,
+		neg:   neg,
+		shift: uint8(bits.TrailingZeros8(uint8(absd))),
+		pow2:  pow2,
+	}
+}
+
+// TryNewInt8 initializes a new pre-computed inverse like NewInt8,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+// Every non-zero int8 is supported, so ErrZeroDivisor is the only error.
+func TryNewInt8(d int8) (Int8, error) {
+	if d == 0 {
+		return Int8{}, ErrZeroDivisor
+	}
+	return NewInt8(d), nil
+}
+
+// Div calculates n / d using the pre-computed inverse.
+func (d Int8) Div(n int8) int8 {
+	neg := d.neg
+	if n < 0 {
+		n = -n
+		neg = !neg
+	}
+	div := uint16(uint8(n))
+	if d.pow2 {
+		div >>= d.shift & 7
+	} else {
+		div, _ = mul16(d.m, div)
+	}
+	if neg {
+		return -int8(div)
+	}
+	return int8(div)
+}
+
+// Mod calculates n % d using the pre-computed inverse.
+func (d Int8) Mod(n int8) int8 {
+	if d.pow2 {
+		mask := int8(d.absd - 1)
+		bias := n >> 7 & mask
+		return (n+bias)&mask - bias
+	}
+	fraction := d.m * uint16(n)
+	mod, _ := mul16(fraction, d.absd)
+	return int8(mod) - (int8(d.absd)-1)&(n>>7)
+}
+
+// DivMod calculates n / d and n % d using the pre-computed inverse.
+func (d Int8) DivMod(n int8) (q, r int8) {
+	var neg bool
+	if n < 0 {
+		n = -n
+		neg = !neg
+	}
+	if d.pow2 {
+		q, r = int8(uint8(n)>>(d.shift&7)), n&int8(d.absd-1)
+	} else {
+		div, fraction := mul16(d.m, uint16(uint8(n)))
+		q = int8(div)
+		mod, _ := mul16(fraction, d.absd)
+		r = int8(mod)
+	}
+	if neg {
+		q = -q
+		r = -r
+	}
+	if d.neg {
+		q = -q
+	}
+	return q, r
+}
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Int8) Divisible(n int8) bool {
+	absn := uint8(n)
+	if n < 0 {
+		absn = -absn
+	}
+	return d.m*uint16(absn) <= d.m-1
+}
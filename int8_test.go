@@ -0,0 +1,166 @@
+package fastdiv
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	sinkInt8 int8 = math.MaxInt8
+	varInt8  int8 = 123
+)
+
+const constInt8 int8 = 123
+
+func TestInt8Exhaustive(t *testing.T) {
+	for y := math.MinInt8; y <= math.MaxInt8; y++ {
+		if y == 0 {
+			continue
+		}
+		d := NewInt8(int8(y))
+		for x := math.MinInt8; x <= math.MaxInt8; x++ {
+			n, y := int8(x), int8(y)
+			q, r := d.DivMod(n)
+			if d.Div(n) != n/y || d.Mod(n) != n%y || q != n/y || r != n%y || d.Divisible(n) != (n%y == 0) {
+				t.Fatalf("%d / %d: got %d, %d, want %d, %d", n, y, q, r, n/y, n%y)
+			}
+		}
+	}
+}
+
+func TestInt8DivSpeed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping speed test in short mode")
+	}
+	const samples = 100
+	results := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < samples; j++ {
+				sinkInt8 = sinkInt8 / varInt8
+			}
+		}
+	})
+	baseline := float64(results.NsPerOp()) / float64(samples)
+	t.Logf("baseline: %2.2f ns/op", baseline)
+
+	benchmarkInt8Div := func(b *testing.B, n int) {
+		for i := 0; i < b.N; i++ {
+			d := NewInt8(varInt8)
+			for j := 0; j < n; j++ {
+				sinkInt8 = d.Div(sinkInt8)
+			}
+		}
+	}
+
+	sizes := []int{1, 2, 3, 5, 8, 10}
+	for _, s := range sizes {
+		results = testing.Benchmark(func(b *testing.B) {
+			benchmarkInt8Div(b, s)
+		})
+		nsPerOp := float64(results.NsPerOp()) / float64(s)
+		t.Logf(" %2d divs: %2.2f ns/op, faster: %v", s, nsPerOp, nsPerOp < baseline)
+	}
+}
+
+func TestInt8ModSpeed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping speed test in short mode")
+	}
+	const samples = 100
+	results := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < samples; j++ {
+				sinkInt8 = sinkInt8 % varInt8
+			}
+		}
+	})
+	baseline := float64(results.NsPerOp()) / float64(samples)
+	t.Logf("baseline: %2.2f ns/op", baseline)
+
+	benchmarkInt8Mod := func(b *testing.B, n int) {
+		for i := 0; i < b.N; i++ {
+			d := NewInt8(varInt8)
+			for j := 0; j < n; j++ {
+				sinkInt8 = d.Mod(sinkInt8)
+			}
+		}
+	}
+
+	sizes := []int{1, 2, 3, 5, 8, 10}
+	for _, s := range sizes {
+		results = testing.Benchmark(func(b *testing.B) {
+			benchmarkInt8Mod(b, s)
+		})
+		nsPerOp := float64(results.NsPerOp()) / float64(s)
+		t.Logf(" %2d mods: %2.2f ns/op, faster: %v", s, nsPerOp, nsPerOp < baseline)
+	}
+}
+
+func BenchmarkInt8DivVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkInt8 = sinkInt8 / varInt8
+	}
+}
+
+func BenchmarkInt8DivConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkInt8 = sinkInt8 / constInt8
+	}
+}
+
+func BenchmarkInt8Div(b *testing.B) {
+	d := NewInt8(varInt8)
+	for i := 0; i < b.N; i++ {
+		sinkInt8 = d.Div(sinkInt8)
+	}
+}
+
+func BenchmarkInt8ModVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkInt8 = sinkInt8 % varInt8
+	}
+}
+
+func BenchmarkInt8ModConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkInt8 = sinkInt8 % constInt8
+	}
+}
+
+func BenchmarkInt8Mod(b *testing.B) {
+	d := NewInt8(varInt8)
+	for i := 0; i < b.N; i++ {
+		sinkInt8 = d.Mod(sinkInt8)
+	}
+}
+
+func BenchmarkInt8DivisibleVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (int8(i) % varInt8) == 0 {
+			sinkInt8 = 2.0
+		} else {
+			sinkInt8 = 1.0
+		}
+	}
+}
+
+func BenchmarkInt8DivisibleConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (int8(i) % constInt8) == 0 {
+			sinkInt8 = 2.0
+		} else {
+			sinkInt8 = 1.0
+		}
+	}
+}
+
+func BenchmarkInt8Divisible(b *testing.B) {
+	d := NewInt8(varInt8)
+	for i := 0; i < b.N; i++ {
+		if d.Divisible(int8(i)) {
+			sinkInt8 = 2.0
+		} else {
+			sinkInt8 = 1.0
+		}
+	}
+}
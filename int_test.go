@@ -0,0 +1,70 @@
+package fastdiv
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+var (
+	sinkInt int = math.MaxInt
+	varInt  int = 123456789
+)
+
+func TestIntDivMod(t *testing.T) {
+	checkIntDivMod := func(x, y int) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewInt(y)
+		q, r := d.DivMod(x)
+		return (x/y) == d.Div(x) && (x%y) == d.Mod(x) && (x/y) == q && (x%y) == r &&
+			((x%y) == 0) == d.Divisible(x)
+	}
+
+	if err := quick.Check(checkIntDivMod, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIntEdge(t *testing.T) {
+	edges := []int{math.MinInt, math.MinInt + 1, -1 << 30, -7, -2, -1, 0, 1, 2, 7, 1 << 30, math.MaxInt/2 + 1, math.MaxInt}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d := NewInt(y)
+		for _, x := range edges {
+			q, r := d.DivMod(x)
+			if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, x/y, x%y)
+			}
+		}
+	}
+}
+
+func BenchmarkIntDivVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkInt = sinkInt / varInt
+	}
+}
+
+func BenchmarkIntDiv(b *testing.B) {
+	d := NewInt(varInt)
+	for i := 0; i < b.N; i++ {
+		sinkInt = d.Div(sinkInt)
+	}
+}
+
+func BenchmarkIntModVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkInt = sinkInt % varInt
+	}
+}
+
+func BenchmarkIntMod(b *testing.B) {
+	d := NewInt(varInt)
+	for i := 0; i < b.N; i++ {
+		sinkInt = d.Mod(sinkInt)
+	}
+}
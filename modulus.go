@@ -0,0 +1,300 @@
+package fastdiv
+
+import "math/bits"
+
+// ModArith64 is the modular arithmetic shared by Modulus64 and Montgomery64,
+// so that chains of multiplications can be written once for both.
+// The arguments and results of the arithmetic are in the representation of the
+// implementation: Encode converts a uint64 into it and Decode converts a result back.
+type ModArith64 interface {
+	Modulus() uint64
+	One() uint64
+	Encode(x uint64) uint64
+	Decode(x uint64) uint64
+	Add(a, b uint64) uint64
+	Sub(a, b uint64) uint64
+	Mul(a, b uint64) uint64
+	Square(a uint64) uint64
+	Pow(a, e uint64) uint64
+}
+
+// ModArith32 is the 32-bit counterpart of ModArith64, shared by Modulus32 and Montgomery32.
+type ModArith32 interface {
+	Modulus() uint32
+	One() uint32
+	Encode(x uint32) uint32
+	Decode(x uint32) uint32
+	Add(a, b uint32) uint32
+	Sub(a, b uint32) uint32
+	Mul(a, b uint32) uint32
+	Square(a uint32) uint32
+	Pow(a uint32, e uint64) uint32
+}
+
+var (
+	_ ModArith64 = Modulus64{}
+	_ ModArith64 = Montgomery64{}
+	_ ModArith32 = Modulus32{}
+	_ ModArith32 = Montgomery32{}
+)
+
+// Modulus64 calculates modular arithmetic by Barrett reduction with the
+// pre-computed inverse of a Uint64.
+// The arguments of Add and Sub must be reduced, i.e. less than the modulus,
+// while Mul, Pow and Inverse accept any uint64 and all results are reduced.
+type Modulus64 struct {
+	u Uint64
+}
+
+// NewModulus64 initializes a new pre-computed inverse for the modulus m != 0.
+// If m == 0, a runtime divide-by-zero panic is raised.
+func NewModulus64(m uint64) Modulus64 {
+	return Modulus64{u: NewUint64(m)}
+}
+
+// TryNewModulus64 initializes a new pre-computed inverse like NewModulus64,
+// but returns ErrZeroDivisor instead of panicking if m == 0.
+func TryNewModulus64(m uint64) (Modulus64, error) {
+	if m == 0 {
+		return Modulus64{}, ErrZeroDivisor
+	}
+	return NewModulus64(m), nil
+}
+
+// Modulus returns the modulus m.
+func (m Modulus64) Modulus() uint64 {
+	return m.u.d
+}
+
+// One returns 1 mod m.
+func (m Modulus64) One() uint64 {
+	return m.Reduce(1)
+}
+
+// Reduce calculates x mod m.
+func (m Modulus64) Reduce(x uint64) uint64 {
+	return m.u.Mod(x)
+}
+
+// Encode calculates x mod m, the representation of x for ModArith64.
+func (m Modulus64) Encode(x uint64) uint64 {
+	return m.Reduce(x)
+}
+
+// Decode returns the reduced x unchanged, since Modulus64 works on plain residues.
+func (m Modulus64) Decode(x uint64) uint64 {
+	return x
+}
+
+// Reduce128 calculates (hi * 2^64 + lo) mod m for any hi and lo.
+func (m Modulus64) Reduce128(hi, lo uint64) uint64 {
+	d := m.u.d
+	if m.u.pow2 {
+		return lo & (d - 1)
+	}
+	// For the inverse M = ceil(2^128 / d) of Uint64, floor(x * M / 2^128) is floor(x / d)
+	// or one more. Leaving out the product of the low words lowers it by at most one,
+	// so the estimate q is within one of floor(x / d).
+	p1hi, p1lo := bits.Mul64(lo, m.u.hi)
+	p2hi, p2lo := bits.Mul64(hi, m.u.lo)
+	p3hi, p3lo := bits.Mul64(hi, m.u.hi)
+	_, c := bits.Add64(p1lo, p2lo, 0)
+	qlo, c := bits.Add64(p1hi, p2hi, c)
+	qhi := p3hi + c
+	qlo, c = bits.Add64(qlo, p3lo, 0)
+	qhi += c
+
+	// r = x - q * d modulo 2^128 is in [-d, 2d)
+	phi, plo := bits.Mul64(qlo, d)
+	phi += qhi * d
+	rlo, b := bits.Sub64(lo, plo, 0)
+	rhi, _ := bits.Sub64(hi, phi, b)
+	if int64(rhi) < 0 {
+		return rlo + d
+	}
+	if rhi != 0 || rlo >= d {
+		return rlo - d
+	}
+	return rlo
+}
+
+// Add calculates (a + b) mod m for reduced a and b.
+func (m Modulus64) Add(a, b uint64) uint64 {
+	s, c := bits.Add64(a, b, 0)
+	if c != 0 || s >= m.u.d {
+		s -= m.u.d
+	}
+	return s
+}
+
+// Sub calculates (a - b) mod m for reduced a and b.
+func (m Modulus64) Sub(a, b uint64) uint64 {
+	r, borrow := bits.Sub64(a, b, 0)
+	if borrow != 0 {
+		r += m.u.d
+	}
+	return r
+}
+
+// Mul calculates a * b mod m from the full 128-bit product.
+func (m Modulus64) Mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return m.Reduce128(hi, lo)
+}
+
+// Square calculates a * a mod m.
+func (m Modulus64) Square(a uint64) uint64 {
+	return m.Mul(a, a)
+}
+
+// Pow calculates a^e mod m by binary exponentiation. Pow(0, 0) is 1 mod m.
+func (m Modulus64) Pow(a, e uint64) uint64 {
+	a = m.Reduce(a)
+	r := m.One()
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = m.Mul(r, a)
+		}
+		a = m.Mul(a, a)
+	}
+	return r
+}
+
+// Inverse calculates the x with a * x mod m == 1 mod m by the extended Euclidean algorithm.
+// If a and m are not coprime, there is no inverse and ok is false.
+func (m Modulus64) Inverse(a uint64) (x uint64, ok bool) {
+	// the invariant r == t * a mod m holds for both pairs
+	r, newr := m.u.d, m.Reduce(a)
+	t, newt := uint64(0), m.One()
+	for newr != 0 {
+		q := r / newr
+		r, newr = newr, r-q*newr
+		t, newt = newt, m.Sub(t, m.Mul(q, newt))
+	}
+	if r != 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// Modulus32 calculates modular arithmetic by Barrett reduction with the
+// pre-computed inverse of a Uint32.
+// The arguments of Add and Sub must be reduced, i.e. less than the modulus,
+// while Mul, Pow and Inverse accept any uint32 and all results are reduced.
+type Modulus32 struct {
+	u Uint32
+}
+
+// NewModulus32 initializes a new pre-computed inverse for the modulus m != 0.
+// If m == 0, a runtime divide-by-zero panic is raised.
+func NewModulus32(m uint32) Modulus32 {
+	return Modulus32{u: NewUint32(m)}
+}
+
+// TryNewModulus32 initializes a new pre-computed inverse like NewModulus32,
+// but returns ErrZeroDivisor instead of panicking if m == 0.
+func TryNewModulus32(m uint32) (Modulus32, error) {
+	if m == 0 {
+		return Modulus32{}, ErrZeroDivisor
+	}
+	return NewModulus32(m), nil
+}
+
+// Modulus returns the modulus m.
+func (m Modulus32) Modulus() uint32 {
+	return uint32(m.u.d)
+}
+
+// One returns 1 mod m.
+func (m Modulus32) One() uint32 {
+	return m.Reduce(1)
+}
+
+// Reduce calculates x mod m.
+func (m Modulus32) Reduce(x uint32) uint32 {
+	return m.u.Mod(x)
+}
+
+// Encode calculates x mod m, the representation of x for ModArith32.
+func (m Modulus32) Encode(x uint32) uint32 {
+	return m.Reduce(x)
+}
+
+// Decode returns the reduced x unchanged, since Modulus32 works on plain residues.
+func (m Modulus32) Decode(x uint32) uint32 {
+	return x
+}
+
+// Reduce64 calculates x mod m for any uint64 x.
+func (m Modulus32) Reduce64(x uint64) uint32 {
+	d := m.u.d
+	if m.u.pow2 {
+		return uint32(x & (d - 1))
+	}
+	// For the inverse M = ceil(2^64 / d) of Uint32, q = floor(x * M / 2^64)
+	// is floor(x / d) or one more, so r = x - q * d is in [-d, d).
+	q, _ := bits.Mul64(x, m.u.m)
+	r := x - q*d
+	if int64(r) < 0 {
+		r += d
+	}
+	return uint32(r)
+}
+
+// Add calculates (a + b) mod m for reduced a and b.
+func (m Modulus32) Add(a, b uint32) uint32 {
+	s := uint64(a) + uint64(b)
+	if s >= m.u.d {
+		s -= m.u.d
+	}
+	return uint32(s)
+}
+
+// Sub calculates (a - b) mod m for reduced a and b.
+func (m Modulus32) Sub(a, b uint32) uint32 {
+	r := a - b
+	if a < b {
+		r += uint32(m.u.d)
+	}
+	return r
+}
+
+// Mul calculates a * b mod m from the full 64-bit product.
+func (m Modulus32) Mul(a, b uint32) uint32 {
+	return m.Reduce64(uint64(a) * uint64(b))
+}
+
+// Square calculates a * a mod m.
+func (m Modulus32) Square(a uint32) uint32 {
+	return m.Mul(a, a)
+}
+
+// Pow calculates a^e mod m by binary exponentiation. Pow(0, 0) is 1 mod m.
+func (m Modulus32) Pow(a uint32, e uint64) uint32 {
+	a = m.Reduce(a)
+	r := m.One()
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = m.Mul(r, a)
+		}
+		a = m.Mul(a, a)
+	}
+	return r
+}
+
+// Inverse calculates the x with a * x mod m == 1 mod m by the extended Euclidean algorithm.
+// If a and m are not coprime, there is no inverse and ok is false.
+func (m Modulus32) Inverse(a uint32) (x uint32, ok bool) {
+	// the invariant r == t * a mod m holds for both pairs
+	r, newr := uint32(m.u.d), m.Reduce(a)
+	t, newt := uint32(0), m.One()
+	for newr != 0 {
+		q := r / newr
+		r, newr = newr, r-q*newr
+		t, newt = newt, m.Sub(t, m.Mul(q, newt))
+	}
+	if r != 1 {
+		return 0, false
+	}
+	return t, true
+}
@@ -0,0 +1,213 @@
+package fastdiv
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"testing"
+	"testing/quick"
+)
+
+// modulusEdges are moduli around powers of two and near the limits of the reduction.
+var modulusEdges = []uint64{1, 2, 3, 5, 7, 1<<31 - 1, 1 << 31, 1<<31 + 1, 1<<32 - 5, 1<<32 - 1, 1 << 32, 1<<32 + 15,
+	1<<61 - 1, 1<<63 - 1, 1 << 63, 1<<63 + 1, math.MaxUint64 - 58, math.MaxUint64 - 1, math.MaxUint64}
+
+func TestModulus64Reduce128(t *testing.T) {
+	check := func(hi, lo, d uint64) bool {
+		if d == 0 {
+			return true
+		}
+		return NewModulus64(d).Reduce128(hi, lo) == bits.Rem64(hi, lo, d)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	// the estimate is off by one for numerators just below and above multiples of d
+	for _, d := range modulusEdges {
+		m := NewModulus64(d)
+		for _, x := range []uint64{0, 1, d - 1, d, d + 1, math.MaxUint64 - 1, math.MaxUint64} {
+			for _, hi := range []uint64{0, 1, d - 1, d, math.MaxUint64} {
+				if got, want := m.Reduce128(hi, x), bits.Rem64(hi, x, d); got != want {
+					t.Errorf("Reduce128(%d, %d) mod %d: got %d, want %d", hi, x, d, got, want)
+				}
+			}
+			// (d-1-k)*(d-1) for small k is close below a multiple of d
+			hi, lo := bits.Mul64(d-1-x%d, d-1)
+			if got, want := m.Reduce128(hi, lo), bits.Rem64(hi, lo, d); got != want {
+				t.Errorf("Reduce128(%d, %d) mod %d: got %d, want %d", hi, lo, d, got, want)
+			}
+		}
+	}
+}
+
+func TestModulus64Arith(t *testing.T) {
+	check := func(a, b, d uint64) bool {
+		if d == 0 {
+			return true
+		}
+		m := NewModulus64(d)
+		ra, rb := a%d, b%d
+		hi, lo := bits.Mul64(a, b)
+		sum := new(big.Int).Add(new(big.Int).SetUint64(ra), new(big.Int).SetUint64(rb))
+		diff := new(big.Int).Sub(new(big.Int).SetUint64(ra), new(big.Int).SetUint64(rb))
+		bd := new(big.Int).SetUint64(d)
+		return m.Modulus() == d && m.Reduce(a) == ra &&
+			m.Add(ra, rb) == sum.Mod(sum, bd).Uint64() &&
+			m.Sub(ra, rb) == diff.Mod(diff, bd).Uint64() &&
+			m.Mul(a, b) == bits.Rem64(hi, lo, d)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModulus64Pow(t *testing.T) {
+	check := func(a, e, d uint64) bool {
+		if d == 0 {
+			return true
+		}
+		bd := new(big.Int).SetUint64(d)
+		want := new(big.Int).Exp(new(big.Int).SetUint64(a), new(big.Int).SetUint64(e), bd)
+		return NewModulus64(d).Pow(a, e) == want.Uint64()
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	for _, d := range modulusEdges {
+		if got, want := NewModulus64(d).Pow(0, 0), 1%d; got != want {
+			t.Errorf("Pow(0, 0) mod %d: got %d, want %d", d, got, want)
+		}
+	}
+}
+
+func TestModulus64Inverse(t *testing.T) {
+	check := func(a, d uint64) bool {
+		if d == 0 {
+			return true
+		}
+		x, ok := NewModulus64(d).Inverse(a)
+		want := new(big.Int).ModInverse(new(big.Int).SetUint64(a), new(big.Int).SetUint64(d))
+		if d == 1 {
+			return ok && x == 0
+		}
+		if want == nil {
+			return !ok && x == 0
+		}
+		return ok && x == want.Uint64()
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	// an inverse exists exactly for numerators coprime to the modulus
+	for _, d := range modulusEdges {
+		m := NewModulus64(d)
+		for _, a := range []uint64{1, 3, d - 1, d + 1, math.MaxUint64} {
+			x, ok := m.Inverse(a)
+			if inv := m.Mul(a, x) == m.Reduce(1); ok != inv || ok != (gcd64(a, d) == 1) {
+				t.Errorf("Inverse(%d) mod %d: got %d, %t", a, d, x, ok)
+			}
+		}
+	}
+}
+
+func gcd64(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func TestModulus32(t *testing.T) {
+	check := func(a, b, d uint32, x, e uint64) bool {
+		if d == 0 {
+			return true
+		}
+		m := NewModulus32(d)
+		d64 := uint64(d)
+		ra, rb := a%d, b%d
+		bd := new(big.Int).SetUint64(d64)
+		pow := new(big.Int).Exp(new(big.Int).SetUint64(uint64(a)), new(big.Int).SetUint64(e), bd)
+		return m.Modulus() == d && m.Reduce(a) == ra && m.Reduce64(x) == uint32(x%d64) &&
+			m.Add(ra, rb) == uint32((uint64(ra)+uint64(rb))%d64) &&
+			m.Sub(ra, rb) == uint32((uint64(ra)+d64-uint64(rb))%d64) &&
+			m.Mul(a, b) == uint32(uint64(a)*uint64(b)%d64) &&
+			m.Pow(a, e) == uint32(pow.Uint64())
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	for _, d64 := range modulusEdges {
+		if d64 > math.MaxUint32 {
+			continue
+		}
+		d := uint32(d64)
+		m := NewModulus32(d)
+		for _, x := range []uint64{0, 1, d64 - 1, d64, d64 + 1, d64 * d64, d64*d64 - 1, math.MaxUint64 - 1, math.MaxUint64} {
+			if got, want := m.Reduce64(x), uint32(x%d64); got != want {
+				t.Errorf("Reduce64(%d) mod %d: got %d, want %d", x, d, got, want)
+			}
+		}
+		for _, a := range []uint32{1, 3, d - 1, d + 1, math.MaxUint32} {
+			x, ok := m.Inverse(a)
+			if inv := m.Mul(a, x) == m.Reduce(1); ok != inv || ok != (gcd64(uint64(a), d64) == 1) {
+				t.Errorf("Inverse(%d) mod %d: got %d, %t", a, d, x, ok)
+			}
+		}
+	}
+}
+
+func TestTryNewModulus(t *testing.T) {
+	if _, err := TryNewModulus64(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewModulus64(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if _, err := TryNewModulus32(0); !errors.Is(err, ErrZeroDivisor) {
+		t.Errorf("TryNewModulus32(0): got %v, want %v", err, ErrZeroDivisor)
+	}
+	if m, err := TryNewModulus64(7); err != nil || m.Modulus() != 7 {
+		t.Errorf("TryNewModulus64(7): got %v, %v", m.Modulus(), err)
+	}
+	if m, err := TryNewModulus32(7); err != nil || m.Modulus() != 7 {
+		t.Errorf("TryNewModulus32(7): got %v, %v", m.Modulus(), err)
+	}
+}
+
+const benchModulus uint64 = 1<<61 - 1
+
+var varModulus = benchModulus
+
+func BenchmarkModulus64MulRem64(b *testing.B) {
+	x := sinkUint64 % varModulus
+	for i := 0; i < b.N; i++ {
+		hi, lo := bits.Mul64(x, x|1)
+		x = bits.Rem64(hi, lo, varModulus)
+	}
+	sinkUint64 = x
+}
+
+func BenchmarkModulus64Mul(b *testing.B) {
+	m := NewModulus64(varModulus)
+	x := sinkUint64 % varModulus
+	for i := 0; i < b.N; i++ {
+		x = m.Mul(x, x|1)
+	}
+	sinkUint64 = x
+}
+
+func BenchmarkModulus32MulVar(b *testing.B) {
+	d := uint64(varUint32)
+	x := uint64(sinkUint32) % d
+	for i := 0; i < b.N; i++ {
+		x = x * (x | 1) % d
+	}
+	sinkUint32 = uint32(x)
+}
+
+func BenchmarkModulus32Mul(b *testing.B) {
+	m := NewModulus32(varUint32)
+	x := m.Reduce(sinkUint32)
+	for i := 0; i < b.N; i++ {
+		x = m.Mul(x, x|1)
+	}
+	sinkUint32 = x
+}
@@ -0,0 +1,302 @@
+package fastdiv
+
+import "math/bits"
+
+// Montgomery64 calculates modular arithmetic for an odd modulus m in Montgomery form,
+// where x is represented by x * 2^64 mod m. A product is reduced with two
+// multiplications and no dependence on a division, which beats Modulus64 for long
+// chains of multiplications such as exponentiation.
+// Values are converted with ToMont and FromMont, and all arguments of the arithmetic
+// must be in Montgomery form, i.e. results of ToMont or of other methods.
+type Montgomery64 struct {
+	m    uint64
+	minv uint64 // m^-1 mod 2^64
+	one  uint64 // 2^64 mod m
+	r2   uint64 // 2^128 mod m
+}
+
+// NewMontgomery64 initializes Montgomery arithmetic for the odd modulus m.
+// If m is even, NewMontgomery64 panics.
+func NewMontgomery64(m uint64) Montgomery64 {
+	mont, err := TryNewMontgomery64(m)
+	if err != nil {
+		panic(err)
+	}
+	return mont
+}
+
+// TryNewMontgomery64 initializes Montgomery arithmetic like NewMontgomery64,
+// but returns ErrZeroDivisor if m == 0 and ErrEvenModulus for any other even m.
+func TryNewMontgomery64(m uint64) (Montgomery64, error) {
+	if m == 0 {
+		return Montgomery64{}, ErrZeroDivisor
+	}
+	if m&1 == 0 {
+		return Montgomery64{}, ErrEvenModulus
+	}
+	// m * m == 1 mod 8 and every Newton step doubles the correct bits
+	minv := m
+	for i := 0; i < 5; i++ {
+		minv *= 2 - m*minv
+	}
+	mod := NewModulus64(m)
+	one := mod.Reduce(-m)
+	return Montgomery64{m: m, minv: minv, one: one, r2: mod.Mul(one, one)}, nil
+}
+
+// redc calculates (hi * 2^64 + lo) * 2^-64 mod m for hi < m.
+func (m Montgomery64) redc(hi, lo uint64) uint64 {
+	// q * m has the same low word as lo, so the difference of the high words is exact
+	q := lo * m.minv
+	h, _ := bits.Mul64(q, m.m)
+	r := hi - h
+	if hi < h {
+		r += m.m
+	}
+	return r
+}
+
+// Modulus returns the modulus m.
+func (m Montgomery64) Modulus() uint64 {
+	return m.m
+}
+
+// One returns 1 in Montgomery form.
+func (m Montgomery64) One() uint64 {
+	return m.one
+}
+
+// ToMont converts any x to Montgomery form.
+func (m Montgomery64) ToMont(x uint64) uint64 {
+	hi, lo := bits.Mul64(x, m.r2)
+	return m.redc(hi, lo)
+}
+
+// FromMont converts x from Montgomery form to x mod m.
+func (m Montgomery64) FromMont(x uint64) uint64 {
+	return m.redc(0, x)
+}
+
+// Encode is ToMont, the conversion of ModArith64.
+func (m Montgomery64) Encode(x uint64) uint64 {
+	return m.ToMont(x)
+}
+
+// Decode is FromMont, the conversion of ModArith64.
+func (m Montgomery64) Decode(x uint64) uint64 {
+	return m.FromMont(x)
+}
+
+// Add calculates a + b in Montgomery form.
+func (m Montgomery64) Add(a, b uint64) uint64 {
+	s, c := bits.Add64(a, b, 0)
+	if c != 0 || s >= m.m {
+		s -= m.m
+	}
+	return s
+}
+
+// Sub calculates a - b in Montgomery form.
+func (m Montgomery64) Sub(a, b uint64) uint64 {
+	r, borrow := bits.Sub64(a, b, 0)
+	if borrow != 0 {
+		r += m.m
+	}
+	return r
+}
+
+// Mul calculates a * b in Montgomery form.
+func (m Montgomery64) Mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return m.redc(hi, lo)
+}
+
+// Square calculates a * a in Montgomery form.
+func (m Montgomery64) Square(a uint64) uint64 {
+	return m.Mul(a, a)
+}
+
+// Pow calculates a^e in Montgomery form by binary exponentiation.
+// Pow(a, 0) is One().
+func (m Montgomery64) Pow(a, e uint64) uint64 {
+	// the squarings of a do not wait for the multiplications into r
+	r := m.one
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = m.Mul(r, a)
+		}
+		a = m.Mul(a, a)
+	}
+	return r
+}
+
+// PowSlice calculates dst[i] = src[i]^e in Montgomery form for every element of src.
+// The exponent is scanned once for four bases at a time, whose independent
+// multiplications overlap in the pipeline. dst must be at least as long as src
+// and may alias it.
+func (m Montgomery64) PowSlice(dst, src []uint64, e uint64) {
+	dst = dst[:len(src)]
+	for len(src) >= 4 {
+		a0, a1, a2, a3 := src[0], src[1], src[2], src[3]
+		r0, r1, r2, r3 := m.one, m.one, m.one, m.one
+		for e := e; e != 0; e >>= 1 {
+			if e&1 != 0 {
+				r0, r1, r2, r3 = m.Mul(r0, a0), m.Mul(r1, a1), m.Mul(r2, a2), m.Mul(r3, a3)
+			}
+			a0, a1, a2, a3 = m.Mul(a0, a0), m.Mul(a1, a1), m.Mul(a2, a2), m.Mul(a3, a3)
+		}
+		dst[0], dst[1], dst[2], dst[3] = r0, r1, r2, r3
+		dst, src = dst[4:], src[4:]
+	}
+	for i, a := range src {
+		dst[i] = m.Pow(a, e)
+	}
+}
+
+// Montgomery32 calculates modular arithmetic for an odd modulus m in Montgomery form,
+// where x is represented by x * 2^32 mod m.
+// Values are converted with ToMont and FromMont, and all arguments of the arithmetic
+// must be in Montgomery form, i.e. results of ToMont or of other methods.
+type Montgomery32 struct {
+	m    uint32
+	minv uint32 // m^-1 mod 2^32
+	one  uint32 // 2^32 mod m
+	r2   uint32 // 2^64 mod m
+}
+
+// NewMontgomery32 initializes Montgomery arithmetic for the odd modulus m.
+// If m is even, NewMontgomery32 panics.
+func NewMontgomery32(m uint32) Montgomery32 {
+	mont, err := TryNewMontgomery32(m)
+	if err != nil {
+		panic(err)
+	}
+	return mont
+}
+
+// TryNewMontgomery32 initializes Montgomery arithmetic like NewMontgomery32,
+// but returns ErrZeroDivisor if m == 0 and ErrEvenModulus for any other even m.
+func TryNewMontgomery32(m uint32) (Montgomery32, error) {
+	if m == 0 {
+		return Montgomery32{}, ErrZeroDivisor
+	}
+	if m&1 == 0 {
+		return Montgomery32{}, ErrEvenModulus
+	}
+	// m * m == 1 mod 8 and every Newton step doubles the correct bits
+	minv := m
+	for i := 0; i < 4; i++ {
+		minv *= 2 - m*minv
+	}
+	mod := NewModulus32(m)
+	one := mod.Reduce64(1 << 32)
+	return Montgomery32{m: m, minv: minv, one: one, r2: mod.Mul(one, one)}, nil
+}
+
+// redc calculates t * 2^-32 mod m for t < m * 2^32.
+func (m Montgomery32) redc(t uint64) uint32 {
+	// q * m has the same low word as t, so the difference of the high words is exact
+	q := uint32(t) * m.minv
+	h := uint32(uint64(q) * uint64(m.m) >> 32)
+	hi := uint32(t >> 32)
+	r := hi - h
+	if hi < h {
+		r += m.m
+	}
+	return r
+}
+
+// Modulus returns the modulus m.
+func (m Montgomery32) Modulus() uint32 {
+	return m.m
+}
+
+// One returns 1 in Montgomery form.
+func (m Montgomery32) One() uint32 {
+	return m.one
+}
+
+// ToMont converts any x to Montgomery form.
+func (m Montgomery32) ToMont(x uint32) uint32 {
+	return m.redc(uint64(x) * uint64(m.r2))
+}
+
+// FromMont converts x from Montgomery form to x mod m.
+func (m Montgomery32) FromMont(x uint32) uint32 {
+	return m.redc(uint64(x))
+}
+
+// Encode is ToMont, the conversion of ModArith32.
+func (m Montgomery32) Encode(x uint32) uint32 {
+	return m.ToMont(x)
+}
+
+// Decode is FromMont, the conversion of ModArith32.
+func (m Montgomery32) Decode(x uint32) uint32 {
+	return m.FromMont(x)
+}
+
+// Add calculates a + b in Montgomery form.
+func (m Montgomery32) Add(a, b uint32) uint32 {
+	s := uint64(a) + uint64(b)
+	if s >= uint64(m.m) {
+		s -= uint64(m.m)
+	}
+	return uint32(s)
+}
+
+// Sub calculates a - b in Montgomery form.
+func (m Montgomery32) Sub(a, b uint32) uint32 {
+	r := a - b
+	if a < b {
+		r += m.m
+	}
+	return r
+}
+
+// Mul calculates a * b in Montgomery form.
+func (m Montgomery32) Mul(a, b uint32) uint32 {
+	return m.redc(uint64(a) * uint64(b))
+}
+
+// Square calculates a * a in Montgomery form.
+func (m Montgomery32) Square(a uint32) uint32 {
+	return m.Mul(a, a)
+}
+
+// Pow calculates a^e in Montgomery form by binary exponentiation.
+// Pow(a, 0) is One().
+func (m Montgomery32) Pow(a uint32, e uint64) uint32 {
+	// the squarings of a do not wait for the multiplications into r
+	r := m.one
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = m.Mul(r, a)
+		}
+		a = m.Mul(a, a)
+	}
+	return r
+}
+
+// PowSlice calculates dst[i] = src[i]^e in Montgomery form for every element of src.
+// The exponent is scanned once for four bases at a time, whose independent
+// multiplications overlap in the pipeline. dst must be at least as long as src
+// and may alias it.
+func (m Montgomery32) PowSlice(dst, src []uint32, e uint64) {
+	dst = dst[:len(src)]
+	for len(src) >= 4 {
+		a0, a1, a2, a3 := src[0], src[1], src[2], src[3]
+		r0, r1, r2, r3 := m.one, m.one, m.one, m.one
+		for e := e; e != 0; e >>= 1 {
+			if e&1 != 0 {
+				r0, r1, r2, r3 = m.Mul(r0, a0), m.Mul(r1, a1), m.Mul(r2, a2), m.Mul(r3, a3)
+			}
+			a0, a1, a2, a3 = m.Mul(a0, a0), m.Mul(a1, a1), m.Mul(a2, a2), m.Mul(a3, a3)
+		}
+		dst[0], dst[1], dst[2], dst[3] = r0, r1, r2, r3
+		dst, src = dst[4:], src[4:]
+	}
+	for i, a := range src {
+		dst[i] = m.Pow(a, e)
+	}
+}
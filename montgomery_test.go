@@ -0,0 +1,256 @@
+package fastdiv
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"testing"
+	"testing/quick"
+)
+
+func TestMontgomery64(t *testing.T) {
+	check := func(a, b, e, d uint64) bool {
+		d |= 1
+		m := NewMontgomery64(d)
+		ma, mb := m.ToMont(a), m.ToMont(b)
+		hi, lo := bits.Mul64(a, b)
+		bd := new(big.Int).SetUint64(d)
+		pow := new(big.Int).Exp(new(big.Int).SetUint64(a), new(big.Int).SetUint64(e), bd)
+		return m.Modulus() == d && m.FromMont(ma) == a%d && m.FromMont(m.One()) == 1%d &&
+			m.FromMont(m.Add(ma, mb)) == NewModulus64(d).Add(a%d, b%d) &&
+			m.FromMont(m.Sub(ma, mb)) == NewModulus64(d).Sub(a%d, b%d) &&
+			m.FromMont(m.Mul(ma, mb)) == bits.Rem64(hi, lo, d) &&
+			m.Square(ma) == m.Mul(ma, ma) &&
+			m.FromMont(m.Pow(ma, e)) == pow.Uint64()
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMontgomery32(t *testing.T) {
+	check := func(a, b, d uint32, e uint64) bool {
+		d |= 1
+		m := NewMontgomery32(d)
+		ma, mb := m.ToMont(a), m.ToMont(b)
+		d64 := uint64(d)
+		bd := new(big.Int).SetUint64(d64)
+		pow := new(big.Int).Exp(new(big.Int).SetUint64(uint64(a)), new(big.Int).SetUint64(e), bd)
+		return m.Modulus() == d && m.FromMont(ma) == a%d && m.FromMont(m.One()) == uint32(1%d64) &&
+			m.FromMont(m.Add(ma, mb)) == uint32((uint64(a%d)+uint64(b%d))%d64) &&
+			m.FromMont(m.Sub(ma, mb)) == uint32((uint64(a%d)+d64-uint64(b%d))%d64) &&
+			m.FromMont(m.Mul(ma, mb)) == uint32(uint64(a)*uint64(b)%d64) &&
+			m.Square(ma) == m.Mul(ma, ma) &&
+			m.FromMont(m.Pow(ma, e)) == uint32(pow.Uint64())
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMontgomeryEdges(t *testing.T) {
+	for _, d := range modulusEdges {
+		if d&1 == 0 {
+			continue
+		}
+		m, mod := NewMontgomery64(d), NewModulus64(d)
+		for _, x := range []uint64{0, 1, 2, d - 1, d, d + 1, math.MaxUint64} {
+			if got, want := m.FromMont(m.Mul(m.ToMont(x), m.ToMont(d-1))), mod.Mul(x, d-1); got != want {
+				t.Errorf("%d * %d mod %d: got %d, want %d", x, d-1, d, got, want)
+			}
+		}
+		if d > math.MaxUint32 {
+			continue
+		}
+		m32, mod32 := NewMontgomery32(uint32(d)), NewModulus32(uint32(d))
+		for _, x := range []uint32{0, 1, 2, uint32(d) - 1, uint32(d), uint32(d) + 1, math.MaxUint32} {
+			if got, want := m32.FromMont(m32.Mul(m32.ToMont(x), m32.ToMont(uint32(d)-1))), mod32.Mul(x, uint32(d)-1); got != want {
+				t.Errorf("%d * %d mod %d: got %d, want %d", x, d-1, d, got, want)
+			}
+		}
+	}
+}
+
+// powChain64 raises x to e with the arithmetic of any ModArith64 by repeated squaring.
+func powChain64(a ModArith64, x, e uint64) uint64 {
+	r, b := a.One(), a.Encode(x)
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = a.Mul(r, b)
+		}
+		b = a.Square(b)
+	}
+	return a.Decode(r)
+}
+
+func powChain32(a ModArith32, x uint32, e uint64) uint32 {
+	r, b := a.One(), a.Encode(x)
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = a.Mul(r, b)
+		}
+		b = a.Square(b)
+	}
+	return a.Decode(r)
+}
+
+func TestModArith(t *testing.T) {
+	check64 := func(x, e, d uint64) bool {
+		d |= 1
+		mod, mont := NewModulus64(d), NewMontgomery64(d)
+		want := mod.Pow(x, e)
+		return powChain64(mod, x, e) == want && powChain64(mont, x, e) == want &&
+			mont.Decode(mont.Pow(mont.Encode(x), e)) == want
+	}
+	if err := quick.Check(check64, nil); err != nil {
+		t.Error(err)
+	}
+	check32 := func(x, d uint32, e uint64) bool {
+		d |= 1
+		mod, mont := NewModulus32(d), NewMontgomery32(d)
+		want := mod.Pow(x, e)
+		return powChain32(mod, x, e) == want && powChain32(mont, x, e) == want &&
+			mont.Decode(mont.Pow(mont.Encode(x), e)) == want
+	}
+	if err := quick.Check(check32, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMontgomeryPowSlice(t *testing.T) {
+	check64 := func(src []uint64, e, d uint64) bool {
+		m := NewMontgomery64(d | 1)
+		for i := range src {
+			src[i] = m.ToMont(src[i])
+		}
+		dst := make([]uint64, len(src)+1)
+		m.PowSlice(dst, src, e)
+		for i, x := range src {
+			if dst[i] != m.Pow(x, e) {
+				return false
+			}
+		}
+		// in place
+		m.PowSlice(src, src, e)
+		for i := range src {
+			if src[i] != dst[i] {
+				return false
+			}
+		}
+		return dst[len(src)] == 0
+	}
+	if err := quick.Check(check64, nil); err != nil {
+		t.Error(err)
+	}
+	check32 := func(src []uint32, d uint32, e uint64) bool {
+		m := NewMontgomery32(d | 1)
+		for i := range src {
+			src[i] = m.ToMont(src[i])
+		}
+		dst := make([]uint32, len(src))
+		m.PowSlice(dst, src, e)
+		for i, x := range src {
+			if dst[i] != m.Pow(x, e) {
+				return false
+			}
+		}
+		m.PowSlice(src, src, e)
+		for i := range src {
+			if src[i] != dst[i] {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(check32, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTryNewMontgomery(t *testing.T) {
+	for _, tc := range []struct {
+		m   uint64
+		err error
+	}{
+		{0, ErrZeroDivisor},
+		{2, ErrEvenModulus},
+		{1 << 32, ErrEvenModulus},
+		{1, nil},
+		{7, nil},
+	} {
+		if _, err := TryNewMontgomery64(tc.m); !errors.Is(err, tc.err) {
+			t.Errorf("TryNewMontgomery64(%d): got %v, want %v", tc.m, err, tc.err)
+		}
+		if tc.m > math.MaxUint32 {
+			continue
+		}
+		if _, err := TryNewMontgomery32(uint32(tc.m)); !errors.Is(err, tc.err) {
+			t.Errorf("TryNewMontgomery32(%d): got %v, want %v", tc.m, err, tc.err)
+		}
+	}
+	defer func() {
+		if r := recover(); r != ErrEvenModulus {
+			t.Errorf("NewMontgomery64(4): got panic %v, want %v", r, ErrEvenModulus)
+		}
+	}()
+	NewMontgomery64(4)
+}
+
+const benchExponent uint64 = 1<<61 - 2
+
+func BenchmarkModulus64Pow(b *testing.B) {
+	m := NewModulus64(varModulus)
+	for i := 0; i < b.N; i++ {
+		sinkUint64 = m.Pow(sinkUint64, benchExponent)
+	}
+}
+
+func BenchmarkMontgomery64Pow(b *testing.B) {
+	m := NewMontgomery64(varModulus)
+	for i := 0; i < b.N; i++ {
+		sinkUint64 = m.Pow(m.ToMont(sinkUint64), benchExponent)
+	}
+}
+
+func BenchmarkMontgomery64PowLoop(b *testing.B) {
+	m := NewMontgomery64(varModulus)
+	src := make([]uint64, 64)
+	for i := range src {
+		src[i] = m.ToMont(uint64(i))
+	}
+	dst := make([]uint64, len(src))
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		for j, x := range src {
+			dst[j] = m.Pow(x, benchExponent)
+		}
+	}
+}
+
+func BenchmarkMontgomery64PowSlice(b *testing.B) {
+	m := NewMontgomery64(varModulus)
+	src := make([]uint64, 64)
+	for i := range src {
+		src[i] = m.ToMont(uint64(i))
+	}
+	dst := make([]uint64, len(src))
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		m.PowSlice(dst, src, benchExponent)
+	}
+}
+
+func BenchmarkModulus32Pow(b *testing.B) {
+	m := NewModulus32(varUint32 | 1)
+	for i := 0; i < b.N; i++ {
+		sinkUint32 = m.Pow(sinkUint32, benchExponent)
+	}
+}
+
+func BenchmarkMontgomery32Pow(b *testing.B) {
+	m := NewMontgomery32(varUint32 | 1)
+	for i := 0; i < b.N; i++ {
+		sinkUint32 = m.Pow(m.ToMont(sinkUint32), benchExponent)
+	}
+}
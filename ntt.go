@@ -0,0 +1,170 @@
+package fastdiv
+
+import (
+	"math/bits"
+	"slices"
+	"strconv"
+)
+
+// NTT calculates number-theoretic transforms, the discrete Fourier transform modulo
+// a prime p, and with them exact polynomial multiplication. The transform length n
+// must be a power of two that divides p - 1, so primes of the form c * 2^k + 1 such
+// as 998244353 = 119 * 2^23 + 1 allow lengths up to 2^k.
+// The butterflies reduce with Montgomery64: the twiddle factors are kept in Montgomery
+// form, so that their products with plain coefficients are plain again.
+// Coefficients must be reduced, i.e. less than p, except for the inputs of Multiply.
+type NTT struct {
+	m    Montgomery64
+	root uint64 // primitive root of p in Montgomery form
+	k    int    // 2^k divides p - 1
+}
+
+// NewNTT initializes number-theoretic transforms modulo the prime p.
+// If p is not an odd prime, NewNTT panics.
+func NewNTT(p uint64) NTT {
+	t, err := TryNewNTT(p)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TryNewNTT initializes number-theoretic transforms like NewNTT,
+// but returns ErrNTTModulus if p is not an odd prime.
+// The primitive root is the smallest g whose power (p - 1) / q is not 1
+// for any prime factor q of p - 1.
+func TryNewNTT(p uint64) (NTT, error) {
+	if p == 2 || !IsPrime64(p) {
+		return NTT{}, ErrNTTModulus
+	}
+	m := NewMontgomery64(p)
+	factors := slices.Compact(Factor64(p - 1))
+	g := m.One()
+	for !isPrimitiveRoot(m, g, factors) {
+		g = m.Add(g, m.One())
+	}
+	return NTT{m: m, root: g, k: bits.TrailingZeros64(p - 1)}, nil
+}
+
+func isPrimitiveRoot(m Montgomery64, g uint64, factors []uint64) bool {
+	p := m.Modulus()
+	for _, q := range factors {
+		if m.Pow(g, (p-1)/q) == m.One() {
+			return false
+		}
+	}
+	return true
+}
+
+// Modulus returns the prime p.
+func (t NTT) Modulus() uint64 {
+	return t.m.Modulus()
+}
+
+// Root returns the primitive root of p from which the roots of unity are taken.
+func (t NTT) Root() uint64 {
+	return t.m.FromMont(t.root)
+}
+
+// MaxLen returns the largest transform length, the largest power of two dividing p - 1
+// that is representable as an int.
+func (t NTT) MaxLen() int {
+	return 1 << min(t.k, strconv.IntSize-2)
+}
+
+// Forward calculates the transform of a in place: a[j] becomes the sum of a[i] * w^(i*j)
+// for the primitive len(a)-th root of unity w = Root()^((p-1)/len(a)).
+// It panics if len(a) is not a power of two up to MaxLen().
+func (t NTT) Forward(a []uint64) {
+	t.transform(a)
+}
+
+// Inverse calculates the inverse transform of a in place, so that Inverse(Forward(a))
+// restores a. It panics if len(a) is not a power of two up to MaxLen().
+func (t NTT) Inverse(a []uint64) {
+	// transforming with w^-1 is transforming with w and reversing all but the first element
+	t.transform(a)
+	slices.Reverse(a[1:])
+	p := t.m.Modulus()
+	ninv := t.m.ToMont(p - (p-1)/uint64(len(a))) // n * (p-1)/n == -1 mod p
+	for i := range a {
+		a[i] = t.m.Mul(a[i], ninv)
+	}
+}
+
+// Multiply returns the product of the polynomials with the coefficients a and b,
+// lowest degree first, modulo p. The coefficients need not be reduced.
+// It panics if len(a) + len(b) - 1 exceeds MaxLen().
+func (t NTT) Multiply(a, b []uint64) []uint64 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	size := len(a) + len(b) - 1
+	n := 1
+	for n < size {
+		n *= 2
+	}
+	fa, fb := make([]uint64, n), make([]uint64, n)
+	// multiplying with the Montgomery form of 1 reduces a plain coefficient
+	for i, x := range a {
+		fa[i] = t.m.Mul(x, t.m.One())
+	}
+	for i, x := range b {
+		fb[i] = t.m.Mul(x, t.m.One())
+	}
+	t.Forward(fa)
+	t.Forward(fb)
+	for i := range fa {
+		fa[i] = t.m.Mul(fa[i], t.m.ToMont(fb[i]))
+	}
+	t.Inverse(fa)
+	return fa[:size]
+}
+
+// transform calculates the forward transform of a in place by iterative radix-2
+// Cooley-Tukey butterflies after a bit reversal permutation.
+func (t NTT) transform(a []uint64) {
+	n := len(a)
+	if n == 0 || n&(n-1) != 0 || n > t.MaxLen() {
+		panic(errNTTLength)
+	}
+	logn := bits.TrailingZeros(uint(n))
+	for i := range a {
+		if j := int(bits.Reverse(uint(i)) >> (bits.UintSize - logn)); i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	if n == 1 {
+		return
+	}
+
+	// twiddles[j] is w^j in Montgomery form, the stage of length 2h uses every n/2h-th
+	p := t.m.Modulus()
+	w := t.m.Pow(t.root, (p-1)/uint64(n))
+	twiddles := make([]uint64, n/2)
+	twiddles[0] = t.m.One()
+	for j := 1; j < n/2; j++ {
+		twiddles[j] = t.m.Mul(twiddles[j-1], w)
+	}
+	// the butterflies are Montgomery64.Mul, Add and Sub with masks instead of branches,
+	// since the comparisons of the random residues of a transform are unpredictable
+	minv := t.m.minv
+	for h := 1; h < n; h *= 2 {
+		step := n / (2 * h)
+		for i := 0; i < n; i += 2 * h {
+			lo, hi := a[i:i+h], a[i+h:i+2*h]
+			for j, u := range lo {
+				vhi, vlo := bits.Mul64(hi[j], twiddles[j*step])
+				q, _ := bits.Mul64(vlo*minv, p)
+				v, borrow := bits.Sub64(vhi, q, 0)
+				v += p & -borrow
+
+				s, c := bits.Add64(u, v, 0)
+				sum, borrow := bits.Sub64(s, p, 0)
+				_, borrow = bits.Sub64(c, 0, borrow)
+				diff, under := bits.Sub64(u, v, 0)
+				lo[j], hi[j] = sum+p&-borrow, diff+p&-under
+			}
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package fastdiv
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// nttPrimes are primes c * 2^k + 1 from 32 to 64 bits.
+var nttPrimes = []uint64{
+	998244353,            // 119 * 2^23 + 1
+	469762049,            // 7 * 2^26 + 1
+	2013265921,           // 15 * 2^27 + 1
+	4179340454199820289,  // 29 * 2^57 + 1
+	18446744069414584321, // 2^64 - 2^32 + 1
+	257,                  // 2^8 + 1
+}
+
+// naiveDFT calculates the transform of a by its definition.
+func naiveDFT(t NTT, a []uint64) []uint64 {
+	m := NewModulus64(t.Modulus())
+	n := uint64(len(a))
+	w := m.Pow(t.Root(), (t.Modulus()-1)/n)
+	out := make([]uint64, len(a))
+	for j := range out {
+		wj := m.Pow(w, uint64(j))
+		for i := len(a) - 1; i >= 0; i-- {
+			out[j] = m.Add(m.Mul(out[j], wj), a[i])
+		}
+	}
+	return out
+}
+
+// naiveMultiply calculates the product of the polynomials a and b by schoolbook multiplication.
+func naiveMultiply(p uint64, a, b []uint64) []uint64 {
+	m := NewModulus64(p)
+	out := make([]uint64, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			out[i+j] = m.Add(out[i+j], m.Mul(x, y))
+		}
+	}
+	return out
+}
+
+func randomPoly(rng *rand.Rand, n int, p uint64) []uint64 {
+	a := make([]uint64, n)
+	for i := range a {
+		a[i] = rng.Uint64() % p
+	}
+	return a
+}
+
+func TestNTT(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, p := range nttPrimes {
+		tr := NewNTT(p)
+		if tr.Modulus() != p || !isPrimitiveRoot(tr.m, tr.m.ToMont(tr.Root()), slices.Compact(Factor64(p-1))) {
+			t.Errorf("p = %d: root %d is not primitive", p, tr.Root())
+		}
+		for n := 1; n <= 64 && n <= tr.MaxLen(); n *= 2 {
+			a := randomPoly(rng, n, p)
+			got := slices.Clone(a)
+			tr.Forward(got)
+			if want := naiveDFT(tr, a); !slices.Equal(got, want) {
+				t.Errorf("p = %d, Forward of length %d: got %v, want %v", p, n, got, want)
+			}
+			tr.Inverse(got)
+			if !slices.Equal(got, a) {
+				t.Errorf("p = %d, Inverse of length %d: got %v, want %v", p, n, got, a)
+			}
+		}
+	}
+}
+
+func TestNTTMultiply(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for _, p := range nttPrimes {
+		tr := NewNTT(p)
+		for _, size := range [][2]int{{1, 1}, {1, 7}, {3, 5}, {16, 17}, {100, 28}, {64, 65}} {
+			if size[0]+size[1]-1 > tr.MaxLen() {
+				continue
+			}
+			a, b := randomPoly(rng, size[0], p), randomPoly(rng, size[1], p)
+			if got, want := tr.Multiply(a, b), naiveMultiply(p, a, b); !slices.Equal(got, want) {
+				t.Errorf("p = %d, %d x %d coefficients: got %v, want %v", p, size[0], size[1], got, want)
+			}
+		}
+		// unreduced coefficients
+		a, b := []uint64{^uint64(0), p, p + 1}, []uint64{^uint64(0) - 1, 2 * p}
+		ra, rb := []uint64{^uint64(0) % p, 0, 1}, []uint64{(^uint64(0) - 1) % p, (2 * p) % p}
+		if got, want := tr.Multiply(a, b), naiveMultiply(p, ra, rb); !slices.Equal(got, want) {
+			t.Errorf("p = %d, unreduced: got %v, want %v", p, got, want)
+		}
+	}
+	if got := NewNTT(998244353).Multiply(nil, []uint64{1}); got != nil {
+		t.Errorf("empty product: got %v", got)
+	}
+}
+
+func TestNTTBigMultiply(t *testing.T) {
+	// 123456789 * 987654321 with base 10 digits, carried after the exact convolution
+	digits := func(s string) []uint64 {
+		d := make([]uint64, len(s))
+		for i := range s {
+			d[len(s)-1-i] = uint64(s[i] - '0')
+		}
+		return d
+	}
+	c := NewNTT(998244353).Multiply(digits("123456789"), digits("987654321"))
+	var carry uint64
+	out := make([]byte, 0, len(c)+1)
+	for _, x := range c {
+		x += carry
+		out = append(out, byte('0'+x%10))
+		carry = x / 10
+	}
+	for ; carry > 0; carry /= 10 {
+		out = append(out, byte('0'+carry%10))
+	}
+	slices.Reverse(out)
+	if got, want := string(out), "121932631112635269"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestTryNewNTT(t *testing.T) {
+	for _, p := range []uint64{0, 1, 2, 4, 9, 998244351, 1<<64 - 1} {
+		if _, err := TryNewNTT(p); !errors.Is(err, ErrNTTModulus) {
+			t.Errorf("TryNewNTT(%d): got %v, want %v", p, err, ErrNTTModulus)
+		}
+	}
+	tr, err := TryNewNTT(998244353)
+	if err != nil || tr.Root() != 3 || tr.MaxLen() != 1<<23 {
+		t.Errorf("TryNewNTT(998244353): got root %d, max length %d, %v", tr.Root(), tr.MaxLen(), err)
+	}
+	// 7 = 3 * 2 + 1 only has transforms of length 1 and 2
+	tr = NewNTT(7)
+	for _, n := range []int{0, 3, 4} {
+		func() {
+			defer func() {
+				if r := recover(); r != errNTTLength {
+					t.Errorf("Forward of length %d: got panic %v, want %v", n, r, errNTTLength)
+				}
+			}()
+			tr.Forward(make([]uint64, n))
+		}()
+	}
+}
+
+func BenchmarkNTTMultiply(b *testing.B) {
+	rng := rand.New(rand.NewSource(3))
+	tr := NewNTT(998244353)
+	x, y := randomPoly(rng, 1024, 998244353), randomPoly(rng, 1024, 998244353)
+	for i := 0; i < b.N; i++ {
+		sinkFactors = tr.Multiply(x, y)
+	}
+}
+
+func BenchmarkNTTForward(b *testing.B) {
+	rng := rand.New(rand.NewSource(4))
+	tr := NewNTT(998244353)
+	x := randomPoly(rng, 1<<12, 998244353)
+	for i := 0; i < b.N; i++ {
+		tr.Forward(x)
+	}
+}
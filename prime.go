@@ -0,0 +1,99 @@
+package fastdiv
+
+import "math/bits"
+
+// primeFilterLimit bounds the small primes that IsPrime64 divides by before Miller-Rabin.
+const primeFilterLimit = 256
+
+// primeGroup is a run of small primes whose product fits in a uint32.
+// A prime divides n exactly if it divides n mod the product, so a single
+// reduction of a 64-bit n leaves a uint32 for the Divisible checks of the group.
+type primeGroup struct {
+	product Modulus32
+	primes  DivisorSet32
+	inv     []uint64 // inverses modulo 2^64 of the odd parts of the primes, for exact division
+}
+
+// smallPrimes holds the primes below primeFilterLimit in ascending order.
+var smallPrimes = newPrimeGroups(primeFilterLimit)
+
+// newPrimeGroups sieves the primes below limit and groups them greedily.
+func newPrimeGroups(limit uint32) []primeGroup {
+	composite := make([]bool, limit)
+	var groups []primeGroup
+	var primes []uint32
+	var inv []uint64
+	product := uint64(1)
+	for p := uint32(2); p < limit; p++ {
+		if composite[p] {
+			continue
+		}
+		for q := p * p; q < limit; q += p {
+			composite[q] = true
+		}
+		if product*uint64(p) > 1<<32-1 {
+			groups = append(groups, primeGroup{NewModulus32(uint32(product)), NewDivisorSet32(primes), inv})
+			primes, inv, product = nil, nil, 1
+		}
+		pinv, _ := oddInverse64(uint64(p))
+		primes = append(primes, p)
+		inv = append(inv, pinv)
+		product *= uint64(p)
+	}
+	if len(primes) > 0 {
+		groups = append(groups, primeGroup{NewModulus32(uint32(product)), NewDivisorSet32(primes), inv})
+	}
+	return groups
+}
+
+// millerRabinBases are the seven bases of Jim Sinclair that make the Miller-Rabin test
+// deterministic for all n < 2^64.
+var millerRabinBases = [...]uint64{2, 325, 9375, 28178, 450775, 9780504, 1795265022}
+
+// IsPrime64 reports whether n is prime. The result is exact for every uint64.
+// Small factors are found by the Divisible checks of a table of pre-computed inverses,
+// and the remaining n are tested by Miller-Rabin with Montgomery arithmetic.
+func IsPrime64(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, g := range smallPrimes {
+		if i := g.primes.FirstDivisor(g.product.Reduce64(n)); i >= 0 {
+			return n == uint64(g.primes.Divisor(i))
+		}
+	}
+	if n < primeFilterLimit*primeFilterLimit {
+		return true
+	}
+	return millerRabin(n)
+}
+
+// millerRabin reports whether the odd n > 2 is a strong probable prime to all millerRabinBases.
+func millerRabin(n uint64) bool {
+	m := NewMontgomery64(n)
+	one := m.One()
+	minusOne := m.Sub(0, one)
+	s := bits.TrailingZeros64(n - 1)
+	d := (n - 1) >> uint(s)
+	for _, a := range millerRabinBases {
+		x := m.ToMont(a)
+		if x == 0 {
+			// n divides a, which is no witness
+			continue
+		}
+		x = m.Pow(x, d)
+		if x == one || x == minusOne {
+			continue
+		}
+		for i := 1; ; i++ {
+			if i == s {
+				return false
+			}
+			x = m.Square(x)
+			if x == minusOne {
+				break
+			}
+		}
+	}
+	return true
+}
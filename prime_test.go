@@ -0,0 +1,112 @@
+package fastdiv
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+var sinkBool bool
+
+// sieve returns the primality of all n < limit by the sieve of Eratosthenes.
+func sieve(limit int) []bool {
+	prime := make([]bool, limit)
+	for i := 2; i < limit; i++ {
+		prime[i] = true
+	}
+	for p := 2; p*p < limit; p++ {
+		if prime[p] {
+			for q := p * p; q < limit; q += p {
+				prime[q] = false
+			}
+		}
+	}
+	return prime
+}
+
+func TestIsPrime64Small(t *testing.T) {
+	for n, want := range sieve(1 << 17) {
+		if got := IsPrime64(uint64(n)); got != want {
+			t.Errorf("IsPrime64(%d): got %t, want %t", n, got, want)
+		}
+	}
+}
+
+func TestIsPrime64(t *testing.T) {
+	check := func(n uint64) bool {
+		return IsPrime64(n) == new(big.Int).SetUint64(n).ProbablyPrime(20)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	// odd n close to a random value hit primes far more often than random n
+	checkNear := func(n uint64) bool {
+		n |= 1
+		for i := uint64(0); i < 64; i += 2 {
+			if IsPrime64(n+i) != new(big.Int).SetUint64(n+i).ProbablyPrime(20) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(checkNear, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIsPrime64Edges(t *testing.T) {
+	for _, tc := range []struct {
+		n    uint64
+		want bool
+	}{
+		{0, false},
+		{1, false},
+		{2, true},
+		{251, true},
+		{257, true},
+		{65521, true},
+		{65537, true},
+		{251 * 251, false},
+		{257 * 257, false},
+		{561, false},        // Carmichael number
+		{3215031751, false}, // strong pseudoprime to bases 2, 3, 5 and 7
+		{4294967291, true},  // largest prime below 2^32
+		{4294967291 * 4294967291, false},
+		{4294967311, true},           // smallest prime above 2^32
+		{3825123056546413051, false}, // strong pseudoprime to the first nine prime bases
+		{1<<61 - 1, true},            // Mersenne prime
+		{1<<63 - 25, true},           // largest prime below 2^63
+		{math.MaxUint64 - 58, true},  // largest prime below 2^64
+		{math.MaxUint64 - 56, false},
+		{math.MaxUint64, false},
+	} {
+		if got := IsPrime64(tc.n); got != tc.want {
+			t.Errorf("IsPrime64(%d): got %t, want %t", tc.n, got, tc.want)
+		}
+	}
+	// a multiple of a base is no witness, which must not make the base itself composite
+	for _, a := range millerRabinBases {
+		if got, want := IsPrime64(a), new(big.Int).SetUint64(a).ProbablyPrime(20); got != want {
+			t.Errorf("IsPrime64(%d): got %t, want %t", a, got, want)
+		}
+	}
+}
+
+func BenchmarkIsPrime64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkBool = IsPrime64(math.MaxUint64 - 58)
+	}
+}
+
+func BenchmarkIsPrime64Big(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkBool = new(big.Int).SetUint64(math.MaxUint64 - 58).ProbablyPrime(0)
+	}
+}
+
+func BenchmarkIsPrime64Odd(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkBool = IsPrime64(uint64(i)<<32 | 1)
+	}
+}
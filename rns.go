@@ -0,0 +1,193 @@
+package fastdiv
+
+import "math/bits"
+
+// RNS is a residue number system over pairwise coprime moduli. An integer is
+// represented by the vector of its residues, which add and multiply component-wise
+// with the pre-computed inverse of each modulus, and is reconstructed by the
+// Chinese Remainder Theorem in the mixed radix form of Garner's algorithm.
+// Moduli below 2^32 use the cheaper arithmetic of Modulus32.
+// Residue vectors must be reduced, i.e. every residue less than its modulus.
+type RNS struct {
+	moduli []rnsChannel
+	garner []uint64 // (m[0] * ... * m[i-1])^-1 mod m[i]
+}
+
+// rnsChannel is the arithmetic modulo one modulus of an RNS,
+// with Modulus32 below 2^32 and Modulus64 otherwise.
+type rnsChannel struct {
+	wide   Modulus64
+	narrow Modulus32
+	small  bool // the modulus is below 2^32 and narrow is used
+}
+
+func newRNSChannel(m uint64) (rnsChannel, error) {
+	if m == 0 {
+		return rnsChannel{}, ErrZeroDivisor
+	}
+	if m < 1<<32 {
+		return rnsChannel{narrow: NewModulus32(uint32(m)), small: true}, nil
+	}
+	return rnsChannel{wide: NewModulus64(m)}, nil
+}
+
+func (c *rnsChannel) modulus() uint64 {
+	if c.small {
+		return uint64(c.narrow.Modulus())
+	}
+	return c.wide.Modulus()
+}
+
+func (c *rnsChannel) reduce(x uint64) uint64 {
+	if c.small {
+		return uint64(c.narrow.Reduce64(x))
+	}
+	return c.wide.Reduce(x)
+}
+
+// add, sub, mul and inverse take reduced arguments.
+
+func (c *rnsChannel) add(a, b uint64) uint64 {
+	if c.small {
+		return uint64(c.narrow.Add(uint32(a), uint32(b)))
+	}
+	return c.wide.Add(a, b)
+}
+
+func (c *rnsChannel) sub(a, b uint64) uint64 {
+	if c.small {
+		return uint64(c.narrow.Sub(uint32(a), uint32(b)))
+	}
+	return c.wide.Sub(a, b)
+}
+
+func (c *rnsChannel) mul(a, b uint64) uint64 {
+	if c.small {
+		return uint64(c.narrow.Mul(uint32(a), uint32(b)))
+	}
+	return c.wide.Mul(a, b)
+}
+
+func (c *rnsChannel) inverse(a uint64) (uint64, bool) {
+	if c.small {
+		x, ok := c.narrow.Inverse(uint32(a))
+		return uint64(x), ok
+	}
+	return c.wide.Inverse(a)
+}
+
+// NewRNS initializes a residue number system for the moduli in order.
+// If a modulus is 0 or two moduli share a factor, NewRNS panics.
+func NewRNS(moduli []uint64) RNS {
+	r, err := TryNewRNS(moduli)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// TryNewRNS initializes a residue number system like NewRNS, but returns
+// ErrZeroDivisor if a modulus is 0 and ErrNotCoprime if two moduli share a factor.
+func TryNewRNS(moduli []uint64) (RNS, error) {
+	r := RNS{moduli: make([]rnsChannel, len(moduli)), garner: make([]uint64, len(moduli))}
+	for i, m := range moduli {
+		c, err := newRNSChannel(m)
+		if err != nil {
+			return RNS{}, err
+		}
+		prod := c.reduce(1)
+		for _, prev := range moduli[:i] {
+			prod = c.mul(prod, c.reduce(prev))
+		}
+		// the product of the previous moduli is invertible exactly if m is coprime to all of them
+		inv, ok := c.inverse(prod)
+		if !ok {
+			return RNS{}, ErrNotCoprime
+		}
+		r.moduli[i], r.garner[i] = c, inv
+	}
+	return r, nil
+}
+
+// Len returns the number of moduli, the length of the residue vectors.
+func (r RNS) Len() int {
+	return len(r.moduli)
+}
+
+// Modulus returns the i-th modulus.
+func (r RNS) Modulus(i int) uint64 {
+	return r.moduli[i].modulus()
+}
+
+// Residues calculates dst[i] = x mod m[i] for every modulus.
+// It panics if len(dst) < r.Len().
+func (r RNS) Residues(x uint64, dst []uint64) {
+	dst = dst[:len(r.moduli)]
+	for i := range r.moduli {
+		dst[i] = r.moduli[i].reduce(x)
+	}
+}
+
+// Add calculates dst = a + b component-wise. The vectors may alias.
+// It panics if any of them is shorter than r.Len().
+func (r RNS) Add(dst, a, b []uint64) {
+	dst, a, b = dst[:len(r.moduli)], a[:len(r.moduli)], b[:len(r.moduli)]
+	for i := range r.moduli {
+		dst[i] = r.moduli[i].add(a[i], b[i])
+	}
+}
+
+// Sub calculates dst = a - b component-wise. The vectors may alias.
+// It panics if any of them is shorter than r.Len().
+func (r RNS) Sub(dst, a, b []uint64) {
+	dst, a, b = dst[:len(r.moduli)], a[:len(r.moduli)], b[:len(r.moduli)]
+	for i := range r.moduli {
+		dst[i] = r.moduli[i].sub(a[i], b[i])
+	}
+}
+
+// Mul calculates dst = a * b component-wise. The vectors may alias.
+// It panics if any of them is shorter than r.Len().
+func (r RNS) Mul(dst, a, b []uint64) {
+	dst, a, b = dst[:len(r.moduli)], a[:len(r.moduli)], b[:len(r.moduli)]
+	for i := range r.moduli {
+		dst[i] = r.moduli[i].mul(a[i], b[i])
+	}
+}
+
+// Reconstruct returns the unique x in [0, m[0] * ... * m[n-1]) with the residues res.
+// If x does not fit in a uint64, which is only possible if the product of the moduli
+// does not, it returns ErrRNSOverflow.
+// It panics if len(res) < r.Len().
+func (r RNS) Reconstruct(res []uint64) (uint64, error) {
+	res = res[:len(r.moduli)]
+	// the mixed radix digits v[i] < m[i] with x = v[0] + m[0] * (v[1] + m[1] * (v[2] + ...))
+	var buf [8]uint64
+	var v []uint64
+	if len(r.moduli) <= len(buf) {
+		v = buf[:len(r.moduli)]
+	} else {
+		v = make([]uint64, len(r.moduli))
+	}
+	for i := range r.moduli {
+		m := &r.moduli[i]
+		var prefix uint64 // v[0] + m[0] * (... + m[i-2] * v[i-1]) mod m[i]
+		for j := i - 1; j >= 0; j-- {
+			prefix = m.add(m.mul(prefix, m.reduce(r.moduli[j].modulus())), m.reduce(v[j]))
+		}
+		v[i] = m.mul(m.sub(res[i], prefix), r.garner[i])
+	}
+
+	var x uint64
+	for i := len(v) - 1; i >= 0; i-- {
+		// the partial sums are the quotients of x by the leading moduli, so any carry
+		// means x itself overflows
+		hi, lo := bits.Mul64(x, r.moduli[i].modulus())
+		lo, c := bits.Add64(lo, v[i], 0)
+		if hi != 0 || c != 0 {
+			return 0, ErrRNSOverflow
+		}
+		x = lo
+	}
+	return x, nil
+}
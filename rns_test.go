@@ -0,0 +1,172 @@
+package fastdiv
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+// rnsModuli are pairwise coprime moduli of several sizes with a product above 2^64.
+var rnsModuli = []uint64{1<<61 - 1, 4294967291, 1 << 20, 65521, 999, 1<<63 - 25, 1000000007, 998244353, 1}
+
+// bigCRT reconstructs the residues with math/big.
+func bigCRT(moduli, res []uint64) *big.Int {
+	x, prod := new(big.Int), big.NewInt(1)
+	for i, m := range moduli {
+		bm := new(big.Int).SetUint64(m)
+		// x += prod * ((res - x) * prod^-1 mod m)
+		t := new(big.Int).Sub(new(big.Int).SetUint64(res[i]), x)
+		inv := new(big.Int).ModInverse(new(big.Int).Mod(prod, bm), bm)
+		if inv == nil {
+			inv = new(big.Int) // m == 1
+		}
+		t.Mul(t, inv).Mod(t, bm)
+		x.Add(x, t.Mul(t, prod))
+		prod.Mul(prod, bm)
+	}
+	return x
+}
+
+func TestRNS(t *testing.T) {
+	for k := 1; k <= len(rnsModuli); k++ {
+		moduli := rnsModuli[:k]
+		r := NewRNS(moduli)
+		if r.Len() != k || r.Modulus(k-1) != moduli[k-1] {
+			t.Errorf("Len %d, Modulus %d", r.Len(), r.Modulus(k-1))
+		}
+		check := func(x, y uint64) bool {
+			a, b, sum, diff, prod := make([]uint64, k), make([]uint64, k), make([]uint64, k), make([]uint64, k), make([]uint64, k)
+			r.Residues(x, a)
+			r.Residues(y, b)
+			r.Add(sum, a, b)
+			r.Sub(diff, a, b)
+			r.Mul(prod, a, b)
+			for i, m := range moduli {
+				if a[i] != x%m {
+					return false
+				}
+			}
+			bx, by := new(big.Int).SetUint64(x), new(big.Int).SetUint64(y)
+			for _, tc := range []struct {
+				res  []uint64
+				want *big.Int
+			}{
+				{a, bx},
+				{sum, new(big.Int).Add(bx, by)},
+				{diff, new(big.Int).Sub(bx, by)},
+				{prod, new(big.Int).Mul(bx, by)},
+			} {
+				// the value modulo the product of the moduli
+				want := bigCRT(moduli, tc.res)
+				got, err := r.Reconstruct(tc.res)
+				if want.IsUint64() != (err == nil) || err == nil && got != want.Uint64() {
+					return false
+				}
+				if err != nil && !errors.Is(err, ErrRNSOverflow) {
+					return false
+				}
+				// the residues must be those of the exact result
+				for i, m := range moduli {
+					if new(big.Int).Mod(tc.want, new(big.Int).SetUint64(m)).Uint64() != tc.res[i] {
+						return false
+					}
+				}
+			}
+			return true
+		}
+		if err := quick.Check(check, nil); err != nil {
+			t.Errorf("%d moduli: %v", k, err)
+		}
+	}
+}
+
+func TestRNSReconstructEdges(t *testing.T) {
+	// the product 2^64 - 1 of the Fermat primes and 641 * 6700417 holds every uint64
+	r := NewRNS([]uint64{3, 5, 17, 257, 641, 65537, 6700417})
+	res := make([]uint64, r.Len())
+	for _, x := range []uint64{0, 1, 1 << 32, math.MaxUint64 - 1} {
+		r.Residues(x, res)
+		if got, err := r.Reconstruct(res); err != nil || got != x {
+			t.Errorf("Reconstruct(Residues(%d)): got %d, %v", x, got, err)
+		}
+	}
+	// 2^64 - 1 is 0 modulo all of them
+	r.Residues(math.MaxUint64, res)
+	if got, err := r.Reconstruct(res); err != nil || got != 0 {
+		t.Errorf("Reconstruct(Residues(MaxUint64)): got %d, %v", got, err)
+	}
+
+	// the moduli on either side of 2^32 take the Modulus32 and Modulus64 channels
+	r = NewRNS([]uint64{1<<32 - 1, 1<<32 + 1})
+	for _, x := range []uint64{0, 1<<32 - 2, 1 << 32, 1<<32 + 1, math.MaxUint64 - 1} {
+		r.Residues(x, res)
+		if res[0] != x%(1<<32-1) || res[1] != x%(1<<32+1) {
+			t.Errorf("Residues(%d): got %v", x, res[:2])
+		}
+		r.Mul(res, res, res)
+		bx := new(big.Int).SetUint64(x)
+		want := bx.Mul(bx, bx).Mod(bx, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+		if got, err := r.Reconstruct(res); err != nil || got != want {
+			t.Errorf("Reconstruct(Residues(%d)^2): got %d, %v, want %d", x, got, err, want)
+		}
+	}
+
+	// -1 modulo a product above 2^64 does not fit
+	r = NewRNS([]uint64{1<<63 - 25, 3})
+	if _, err := r.Reconstruct([]uint64{1<<63 - 26, 2}); !errors.Is(err, ErrRNSOverflow) {
+		t.Errorf("got %v, want %v", err, ErrRNSOverflow)
+	}
+	// more moduli than the stack buffer of Reconstruct
+	many := []uint64{3, 5, 7, 11, 13, 17, 19, 23, 29, 31}
+	r = NewRNS(many)
+	res = make([]uint64, len(many))
+	r.Residues(123456789, res)
+	if got, err := r.Reconstruct(res); err != nil || got != 123456789 {
+		t.Errorf("Reconstruct with %d moduli: got %d, %v", len(many), got, err)
+	}
+}
+
+func TestTryNewRNS(t *testing.T) {
+	for _, tc := range []struct {
+		moduli []uint64
+		err    error
+	}{
+		{[]uint64{3, 0}, ErrZeroDivisor},
+		{[]uint64{6, 35, 15}, ErrNotCoprime},
+		{[]uint64{1<<32 + 1, 641}, ErrNotCoprime}, // 2^32 + 1 = 641 * 6700417
+		{[]uint64{7, 7}, ErrNotCoprime},
+		{[]uint64{6, 35, 11}, nil},
+		{nil, nil},
+	} {
+		if _, err := TryNewRNS(tc.moduli); !errors.Is(err, tc.err) {
+			t.Errorf("TryNewRNS(%v): got %v, want %v", tc.moduli, err, tc.err)
+		}
+	}
+	defer func() {
+		if r := recover(); r != ErrNotCoprime {
+			t.Errorf("NewRNS: got panic %v, want %v", r, ErrNotCoprime)
+		}
+	}()
+	NewRNS([]uint64{4, 6})
+}
+
+func BenchmarkRNSMul(b *testing.B) {
+	r := NewRNS(rnsModuli[:4])
+	x, y := make([]uint64, r.Len()), make([]uint64, r.Len())
+	r.Residues(123456789, x)
+	r.Residues(987654321, y)
+	for i := 0; i < b.N; i++ {
+		r.Mul(x, x, y)
+	}
+}
+
+func BenchmarkRNSReconstruct(b *testing.B) {
+	r := NewRNS([]uint64{3, 5, 17, 257, 641, 65537, 6700417})
+	res := make([]uint64, r.Len())
+	r.Residues(123456789, res)
+	for i := 0; i < b.N; i++ {
+		sinkUint64, _ = r.Reconstruct(res)
+	}
+}
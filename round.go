@@ -0,0 +1,135 @@
+package fastdiv
+
+import "fmt"
+
+// RoundingMode specifies how DivRound rounds a quotient that is not exact.
+type RoundingMode uint8
+
+// The rounding modes supported by DivRound.
+const (
+	RoundFloor    RoundingMode = iota // toward negative infinity
+	RoundCeil                         // toward positive infinity
+	RoundHalfUp                       // to nearest, ties away from zero
+	RoundHalfDown                     // to nearest, ties toward zero
+	RoundHalfEven                     // to nearest, ties to even
+)
+
+var roundingModeNames = [...]string{
+	RoundFloor:    "RoundFloor",
+	RoundCeil:     "RoundCeil",
+	RoundHalfUp:   "RoundHalfUp",
+	RoundHalfDown: "RoundHalfDown",
+	RoundHalfEven: "RoundHalfEven",
+}
+
+func (mode RoundingMode) String() string {
+	if int(mode) < len(roundingModeNames) {
+		return roundingModeNames[mode]
+	}
+	return fmt.Sprintf("RoundingMode(%d)", uint8(mode))
+}
+
+// round adjusts the truncated quotient q and remainder r of a division by a divisor
+// with absolute value absd, which is negative if neg, according to mode.
+// The adjustment compares |r| with |d| - |r| so it never overflows, and the
+// quotient only moves away from zero when |d| > 1, so it never overflows either.
+// An invalid mode causes a panic.
+func round[T Integer](q, r T, absd uint64, neg bool, mode RoundingMode) T {
+	if r == 0 {
+		return q
+	}
+	absr := uint64(r)
+	if r < 0 {
+		absr = -absr
+	}
+	negq := (r < 0) != neg
+
+	var away bool
+	switch mode {
+	case RoundFloor:
+		away = negq
+	case RoundCeil:
+		away = !negq
+	case RoundHalfUp:
+		away = absr >= absd-absr
+	case RoundHalfDown:
+		away = absr > absd-absr
+	case RoundHalfEven:
+		away = absr > absd-absr || absr == absd-absr && q&1 != 0
+	default:
+		panic("fastdiv: invalid rounding mode " + mode.String())
+	}
+	if !away {
+		return q
+	}
+	if negq {
+		return q - 1
+	}
+	return q + 1
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Uint8) DivRound(n uint8, mode RoundingMode) uint8 {
+	q, r := d.DivMod(n)
+	return round(q, r, uint64(d.d), false, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Uint16) DivRound(n uint16, mode RoundingMode) uint16 {
+	q, r := d.DivMod(n)
+	return round(q, r, uint64(d.d), false, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Uint32) DivRound(n uint32, mode RoundingMode) uint32 {
+	q, r := d.DivMod(n)
+	return round(q, r, uint64(d.d), false, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Uint64) DivRound(n uint64, mode RoundingMode) uint64 {
+	q, r := d.DivMod(n)
+	return round(q, r, uint64(d.d), false, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Int8) DivRound(n int8, mode RoundingMode) int8 {
+	q, r := d.DivMod(n)
+	return round(q, r, uint64(d.absd), d.neg, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Int16) DivRound(n int16, mode RoundingMode) int16 {
+	q, r := d.DivMod(n)
+	return round(q, r, uint64(d.absd), d.neg, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Int32) DivRound(n int32, mode RoundingMode) int32 {
+	q, r := d.DivMod(n)
+	return round(q, r, uint64(d.absd), d.neg, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Int64) DivRound(n int64, mode RoundingMode) int64 {
+	q, r := d.DivMod(n)
+	return round(q, r, uint64(d.absd), d.neg, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the magic number multiplier.
+func (d Uint64GMW) DivRound(n uint64, mode RoundingMode) uint64 {
+	q, r := d.DivMod(n)
+	return round(q, r, d.d, false, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the magic number multiplier.
+func (d Int64GMW) DivRound(n int64, mode RoundingMode) int64 {
+	q, r := d.DivMod(n)
+	return round(q, r, d.absd, d.neg, mode)
+}
+
+// DivRound calculates n / d rounded according to mode using the strategy of d.
+func (d AutoUint64) DivRound(n uint64, mode RoundingMode) uint64 {
+	q, r := d.DivMod(n)
+	return round(q, r, d.d, false, mode)
+}
@@ -0,0 +1,210 @@
+package fastdiv
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+var roundingModes = []RoundingMode{RoundFloor, RoundCeil, RoundHalfUp, RoundHalfDown, RoundHalfEven}
+
+// divRound is the reference rounded division computed exactly with math/big.
+func divRound(n, d *big.Int, mode RoundingMode) *big.Int {
+	a, b := new(big.Int).Set(n), new(big.Int).Set(d)
+	if b.Sign() < 0 {
+		a.Neg(a)
+		b.Neg(b)
+	}
+	// with b > 0 the Euclidean quotient is the floor
+	floor := new(big.Int).Div(a, b)
+	switch mode {
+	case RoundFloor:
+		return floor
+	case RoundCeil:
+		return floor.Neg(floor.Div(new(big.Int).Neg(a), b))
+	}
+	// floor(2a/b + 1) / 2 rounds ties up, then fix ties according to mode
+	twice := new(big.Int).Lsh(a, 1)
+	half := new(big.Int).Div(new(big.Int).Add(twice, b), new(big.Int).Lsh(b, 1))
+	if new(big.Int).Mod(twice, b).Sign() != 0 || new(big.Int).Mod(a, b).Sign() == 0 {
+		return half
+	}
+	switch mode {
+	case RoundHalfUp:
+		if a.Sign() < 0 {
+			return floor
+		}
+	case RoundHalfDown:
+		if a.Sign() > 0 {
+			return floor
+		}
+	case RoundHalfEven:
+		if half.Bit(0) != 0 {
+			return floor
+		}
+	}
+	return half
+}
+
+func checkDivRound[T Integer](x, y, got T, mode RoundingMode) bool {
+	var n, d *big.Int
+	if isSigned[T]() {
+		n, d = big.NewInt(int64(x)), big.NewInt(int64(y))
+	} else {
+		n, d = new(big.Int).SetUint64(uint64(x)), new(big.Int).SetUint64(uint64(y))
+	}
+	want := divRound(n, d, mode)
+	if isSigned[T]() {
+		return want.IsInt64() && want.Int64() == int64(got)
+	}
+	return want.IsUint64() && want.Uint64() == uint64(got)
+}
+
+func TestUint8DivRoundExhaustive(t *testing.T) {
+	for y := 1; y <= math.MaxUint8; y++ {
+		d := NewUint8(uint8(y))
+		for x := 0; x <= math.MaxUint8; x++ {
+			for _, mode := range roundingModes {
+				if got := d.DivRound(uint8(x), mode); !checkDivRound(uint8(x), uint8(y), got, mode) {
+					t.Fatalf("%v(%d / %d) = %d", mode, x, y, got)
+				}
+			}
+		}
+	}
+}
+
+func TestInt8DivRoundExhaustive(t *testing.T) {
+	for y := math.MinInt8; y <= math.MaxInt8; y++ {
+		if y == 0 {
+			continue
+		}
+		d := NewInt8(int8(y))
+		for x := math.MinInt8; x <= math.MaxInt8; x++ {
+			if x == math.MinInt8 && y == -1 {
+				continue // the quotient overflows
+			}
+			for _, mode := range roundingModes {
+				if got := d.DivRound(int8(x), mode); !checkDivRound(int8(x), int8(y), got, mode) {
+					t.Fatalf("%v(%d / %d) = %d", mode, x, y, got)
+				}
+			}
+		}
+	}
+}
+
+func TestUint64DivRound(t *testing.T) {
+	checkUint64DivRound := func(x, y uint64) bool {
+		if y == 0 {
+			return true
+		}
+		d, g := NewUint64(y), NewUint64GMW(y)
+		for _, mode := range roundingModes {
+			if !checkDivRound(x, y, d.DivRound(x, mode), mode) || !checkDivRound(x, y, g.DivRound(x, mode), mode) {
+				return false
+			}
+			for _, s := range autoStrategies {
+				if !checkDivRound(x, y, newAutoUint64(y, s).DivRound(x, mode), mode) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(checkUint64DivRound, nil); err != nil {
+		t.Error(err)
+	}
+	edges := []uint64{1, 2, 3, math.MaxUint64/2 - 1, math.MaxUint64 / 2, math.MaxUint64/2 + 1, math.MaxUint64 - 1, math.MaxUint64}
+	for _, y := range edges {
+		for _, x := range edges {
+			if !checkUint64DivRound(x, y) {
+				t.Errorf("DivRound(%d / %d) mismatch", x, y)
+			}
+		}
+	}
+}
+
+func TestInt64DivRound(t *testing.T) {
+	checkInt64DivRound := func(x, y int64) bool {
+		if y == 0 || x == math.MinInt64 && y == -1 {
+			return true
+		}
+		d, g := NewInt64(y), NewInt64GMW(y)
+		for _, mode := range roundingModes {
+			if !checkDivRound(x, y, d.DivRound(x, mode), mode) || !checkDivRound(x, y, g.DivRound(x, mode), mode) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(checkInt64DivRound, nil); err != nil {
+		t.Error(err)
+	}
+	edges := []int64{math.MinInt64, math.MinInt64 + 1, math.MinInt64 / 2, -3, -2, -1, 1, 2, 3, math.MaxInt64 / 2, math.MaxInt64 - 1, math.MaxInt64}
+	for _, y := range edges {
+		for _, x := range edges {
+			if !checkInt64DivRound(x, y) {
+				t.Errorf("DivRound(%d / %d) mismatch", x, y)
+			}
+		}
+	}
+}
+
+func testDivRound[T Integer](t *testing.T) {
+	checkDivisorDivRound := func(x, y T) bool {
+		if y == 0 || isSigned[T]() && y == ^T(0) {
+			return true
+		}
+		d := New(y)
+		for _, mode := range roundingModes {
+			if !checkDivRound(x, y, d.DivRound(x, mode), mode) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(checkDivisorDivRound, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	t.Run("Uint16", testDivRound[uint16])
+	t.Run("Uint32", testDivRound[uint32])
+	t.Run("Int16", testDivRound[int16])
+	t.Run("Int32", testDivRound[int32])
+	t.Run("Uint", func(t *testing.T) {
+		check := func(x, y uint) bool {
+			return y == 0 || NewUint(y).DivRound(x, RoundHalfEven) == New(y).DivRound(x, RoundHalfEven)
+		}
+		if err := quick.Check(check, nil); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("Int", func(t *testing.T) {
+		check := func(x, y int) bool {
+			return y == 0 || NewInt(y).DivRound(x, RoundHalfUp) == New(y).DivRound(x, RoundHalfUp)
+		}
+		if err := quick.Check(check, nil); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestDivRoundInvalidMode(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("DivRound did not panic for an invalid rounding mode")
+		}
+	}()
+	NewUint32(3).DivRound(7, RoundingMode(255))
+}
+
+func BenchmarkUint32DivRound(b *testing.B) {
+	d := NewUint32(varUint32)
+	for i := 0; i < b.N; i++ {
+		sinkUint32 = d.DivRound(sinkUint32+uint32(i), RoundHalfEven)
+	}
+}
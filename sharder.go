@@ -0,0 +1,162 @@
+package fastdiv
+
+import (
+	"hash/maphash"
+	"math"
+	"sync"
+	"unsafe"
+)
+
+// Sharder maps keys to a fixed number of shards by hashing them and reducing
+// the full 64-bit hash with the pre-computed inverse of a Uint64.
+// A Sharder is immutable and safe for concurrent use.
+type Sharder struct {
+	n    Uint64
+	seed maphash.Seed
+	salt uint64 // mixed into integer keys
+	hash func(key []byte) uint64
+}
+
+// NewSharder initializes a new Sharder for n shards that hashes keys with hash/maphash.
+// The seed is random, so the shard of a key differs between processes.
+// If n < 1 or n > math.MaxUint32, NewSharder panics.
+func NewSharder(n int) Sharder {
+	s, err := TryNewSharder(n)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// TryNewSharder initializes a new Sharder like NewSharder, but returns
+// ErrZeroDivisor if n == 0 and ErrShardCount if n < 0 or n > math.MaxUint32.
+func TryNewSharder(n int) (Sharder, error) {
+	if err := checkShardCount(n); err != nil {
+		return Sharder{}, err
+	}
+	seed := maphash.MakeSeed()
+	return Sharder{n: NewUint64(uint64(n)), seed: seed, salt: maphash.String(seed, "")}, nil
+}
+
+// NewSharderFunc initializes a new Sharder for n shards that hashes keys with hash,
+// which must not modify or retain the key. Unlike NewSharder the shard of a key
+// only depends on hash, so it is stable between processes.
+// If n < 1 or n > math.MaxUint32, NewSharderFunc panics.
+func NewSharderFunc(n int, hash func(key []byte) uint64) Sharder {
+	s, err := TryNewSharderFunc(n, hash)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// TryNewSharderFunc initializes a new Sharder like NewSharderFunc, but returns
+// ErrZeroDivisor if n == 0 and ErrShardCount if n < 0 or n > math.MaxUint32.
+func TryNewSharderFunc(n int, hash func(key []byte) uint64) (Sharder, error) {
+	if err := checkShardCount(n); err != nil {
+		return Sharder{}, err
+	}
+	return Sharder{n: NewUint64(uint64(n)), hash: hash}, nil
+}
+
+func checkShardCount(n int) error {
+	if n == 0 {
+		return ErrZeroDivisor
+	}
+	if n < 0 || uint64(n) > math.MaxUint32 {
+		return ErrShardCount
+	}
+	return nil
+}
+
+// Len returns the number of shards.
+func (s Sharder) Len() int {
+	return int(s.n.d)
+}
+
+// Shard returns the shard of key in [0, s.Len()).
+func (s Sharder) Shard(key []byte) int {
+	if s.hash != nil {
+		return s.reduce(s.hash(key))
+	}
+	return s.reduce(maphash.Bytes(s.seed, key))
+}
+
+// ShardString returns the shard of key in [0, s.Len()).
+// It is equal to Shard([]byte(key)) but does not copy the key.
+func (s Sharder) ShardString(key string) int {
+	if s.hash != nil {
+		return s.reduce(s.hash(unsafe.Slice(unsafe.StringData(key), len(key))))
+	}
+	return s.reduce(maphash.String(s.seed, key))
+}
+
+// ShardUint64 returns the shard of the integer key in [0, s.Len()).
+// Integer keys are not passed to the hash function, but mixed with a bijective
+// finalizer so that sequential keys spread over all shards.
+// For a Sharder from NewSharderFunc the mixing is unseeded and stable between processes.
+func (s Sharder) ShardUint64(key uint64) int {
+	key ^= s.salt
+	key = (key ^ key>>30) * 0xbf58476d1ce4e5b9
+	key = (key ^ key>>27) * 0x94d049bb133111eb
+	return s.reduce(key ^ key>>31)
+}
+
+// reduce returns the hash h modulo the number of shards.
+func (s Sharder) reduce(h uint64) int {
+	return int(s.n.Mod(h))
+}
+
+// cacheLineSize is the padding that keeps the stripes of a StripedMutex apart.
+const cacheLineSize = 64
+
+type paddedMutex struct {
+	sync.Mutex
+	_ [cacheLineSize - unsafe.Sizeof(sync.Mutex{})%cacheLineSize]byte
+}
+
+// StripedMutex guards a keyed resource with a fixed number of mutexes,
+// so that keys in different stripes can be locked concurrently.
+// Keys are hashed with hash/maphash and the stripe count need not be a power of two.
+// Each mutex is padded to its own cache line.
+type StripedMutex[K comparable] struct {
+	s       Sharder
+	stripes []paddedMutex
+}
+
+// NewStripedMutex initializes a new StripedMutex with n stripes.
+// If n < 1 or n > math.MaxUint32, NewStripedMutex panics.
+func NewStripedMutex[K comparable](n int) *StripedMutex[K] {
+	return &StripedMutex[K]{s: NewSharder(n), stripes: make([]paddedMutex, n)}
+}
+
+// Len returns the number of stripes.
+func (m *StripedMutex[K]) Len() int {
+	return len(m.stripes)
+}
+
+// Stripe returns the stripe of key in [0, m.Len()).
+func (m *StripedMutex[K]) Stripe(key K) int {
+	return m.s.reduce(maphash.Comparable(m.s.seed, key))
+}
+
+// Lock locks the stripe of key.
+func (m *StripedMutex[K]) Lock(key K) {
+	m.stripes[m.Stripe(key)].Lock()
+}
+
+// TryLock tries to lock the stripe of key and reports whether it succeeded.
+func (m *StripedMutex[K]) TryLock(key K) bool {
+	return m.stripes[m.Stripe(key)].TryLock()
+}
+
+// Unlock unlocks the stripe of key.
+// It is a run-time error if the stripe is not locked on entry to Unlock.
+func (m *StripedMutex[K]) Unlock(key K) {
+	m.stripes[m.Stripe(key)].Unlock()
+}
+
+// Locker returns a sync.Locker for the stripe of key.
+func (m *StripedMutex[K]) Locker(key K) sync.Locker {
+	return &m.stripes[m.Stripe(key)]
+}
@@ -0,0 +1,176 @@
+package fastdiv
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"hash/maphash"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"testing/quick"
+)
+
+var sinkShard int
+
+func fnv64(key []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(key)
+	return h.Sum64()
+}
+
+func TestSharder(t *testing.T) {
+	ns := []int{1, 2, 3, 7, 10, 64, 1000, 1 << 20, math.MaxInt32}
+	if strconv.IntSize == 64 {
+		var most uint64 = math.MaxUint32
+		ns = append(ns, int(most))
+	}
+	for _, n := range ns {
+		for _, s := range []Sharder{NewSharder(n), NewSharderFunc(n, fnv64)} {
+			if s.Len() != n {
+				t.Errorf("Len: got %d, want %d", s.Len(), n)
+			}
+			check := func(key []byte, x uint64) bool {
+				i := s.Shard(key)
+				return i >= 0 && i < n && i == s.ShardString(string(key)) &&
+					i == s.Shard(append([]byte(nil), key...)) &&
+					s.ShardUint64(x) >= 0 && s.ShardUint64(x) < n
+			}
+			if err := quick.Check(check, nil); err != nil {
+				t.Errorf("n = %d: %v", n, err)
+			}
+		}
+	}
+}
+
+func TestSharderFuncStable(t *testing.T) {
+	s := NewSharderFunc(1000, fnv64)
+	for _, key := range []string{"", "a", "user:12345", "the quick brown fox"} {
+		h := fnv64([]byte(key))
+		if got, want := s.ShardString(key), int(h%1000); got != want {
+			t.Errorf("ShardString(%q): got %d, want %d", key, got, want)
+		}
+	}
+	if NewSharderFunc(1000, fnv64).ShardUint64(42) != s.ShardUint64(42) {
+		t.Error("ShardUint64 differs between Sharders with the same hash function")
+	}
+}
+
+func TestSharderUniform(t *testing.T) {
+	const n, perShard = 37, 1000
+	s := NewSharder(n)
+	var bytesCount, intCount [n]int
+	var key [8]byte
+	for i := uint64(0); i < n*perShard; i++ {
+		binary.LittleEndian.PutUint64(key[:], i)
+		bytesCount[s.Shard(key[:])]++
+		intCount[s.ShardUint64(i)]++
+	}
+	// a chi-squared statistic above 100 has a probability below 1e-7 for 36 degrees of freedom
+	for name, counts := range map[string][n]int{"Shard": bytesCount, "ShardUint64": intCount} {
+		var chi2 float64
+		for _, c := range counts {
+			chi2 += float64((c-perShard)*(c-perShard)) / perShard
+		}
+		if chi2 > 100 {
+			t.Errorf("%s: chi-squared %.1f for counts %v", name, chi2, counts)
+		}
+	}
+}
+
+func TestTryNewSharder(t *testing.T) {
+	for _, tc := range []struct {
+		n   int
+		err error
+	}{
+		{0, ErrZeroDivisor},
+		{-1, ErrShardCount},
+		{1, nil},
+	} {
+		if _, err := TryNewSharder(tc.n); !errors.Is(err, tc.err) {
+			t.Errorf("TryNewSharder(%d): got %v, want %v", tc.n, err, tc.err)
+		}
+		if _, err := TryNewSharderFunc(tc.n, fnv64); !errors.Is(err, tc.err) {
+			t.Errorf("TryNewSharderFunc(%d): got %v, want %v", tc.n, err, tc.err)
+		}
+	}
+	if strconv.IntSize == 64 {
+		var tooMany uint64 = math.MaxUint32 + 1
+		n := int(tooMany)
+		if _, err := TryNewSharder(n); !errors.Is(err, ErrShardCount) {
+			t.Errorf("TryNewSharder(%d): got %v, want %v", n, err, ErrShardCount)
+		}
+	}
+	defer func() {
+		if r := recover(); r != ErrZeroDivisor {
+			t.Errorf("NewSharder(0): got panic %v, want %v", r, ErrZeroDivisor)
+		}
+	}()
+	NewSharder(0)
+}
+
+func TestStripedMutex(t *testing.T) {
+	const stripes, keys, workers, rounds = 7, 50, 8, 1000
+	m := NewStripedMutex[int](stripes)
+	if m.Len() != stripes {
+		t.Errorf("Len: got %d, want %d", m.Len(), stripes)
+	}
+	for k := 0; k < keys; k++ {
+		if i := m.Stripe(k); i < 0 || i >= stripes || i != m.Stripe(k) {
+			t.Errorf("Stripe(%d): got %d", k, i)
+		}
+	}
+
+	// every counter is only guarded by the stripe of its key
+	counts := make([]int, keys)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				k := (r*workers + w) % keys
+				m.Lock(k)
+				counts[k]++
+				m.Unlock(k)
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	if total != workers*rounds {
+		t.Errorf("lost updates: got %d, want %d", total, workers*rounds)
+	}
+
+	if !m.TryLock(1) || m.TryLock(1) {
+		t.Error("TryLock of a free stripe failed or of a locked stripe succeeded")
+	}
+	m.Locker(1).Unlock()
+}
+
+func BenchmarkShardString(b *testing.B) {
+	s := NewSharder(1000)
+	for i := 0; i < b.N; i++ {
+		sinkShard = s.ShardString("user:123456789")
+	}
+}
+
+func BenchmarkShardStringMod(b *testing.B) {
+	seed := maphash.MakeSeed()
+	n := uint64(1000)
+	for i := 0; i < b.N; i++ {
+		sinkShard = int(maphash.String(seed, "user:123456789") % n)
+	}
+}
+
+func BenchmarkShardUint64(b *testing.B) {
+	s := NewSharder(1000)
+	for i := 0; i < b.N; i++ {
+		sinkShard = s.ShardUint64(uint64(i))
+	}
+}
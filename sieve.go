@@ -0,0 +1,162 @@
+package fastdiv
+
+import (
+	"iter"
+	"math"
+)
+
+// sieveSegment is the number of odd numbers sieved at a time,
+// one byte each to fill a typical 32 KiB L1 data cache.
+const sieveSegment = 1 << 15
+
+// sieveBaseLimit bounds the number of base primes a Sieve keeps, 8 MiB with their offsets.
+// The base primes beyond it are only needed above about 2^48 and are sieved again
+// for every segment.
+const sieveBaseLimit = 1 << 20
+
+// basePrime is a base prime with the index of its next odd multiple in the current segment.
+type basePrime struct {
+	p, next uint32
+}
+
+// Sieve returns an iterator over the primes in [lo, hi) in ascending order.
+// The range is sieved in cache-sized segments of odd numbers, so the memory does
+// not grow with hi - lo. The odd base primes up to √hi are sieved as the segments
+// need them by the tiny primes up to the fourth root of hi, whose pre-computed
+// inverses find their first multiple in each chunk with a single Reduce64.
+// At most sieveBaseLimit base primes are kept as uint32 with the offset of their
+// next multiple, which carries over from one segment to the next, so the memory
+// stays bounded for any hi. The rest are sieved again in chunks for every segment,
+// which makes the time of a segment near 2^64 grow with √hi, about 2^32.
+func Sieve(lo, hi uint64) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		if lo <= 2 && hi > 2 && !yield(2) {
+			return
+		}
+		lo = max(lo, 3) | 1
+		if lo >= hi {
+			return
+		}
+		tiny := tinyPrimes(isqrt(isqrt(hi - 1)))
+		var base []basePrime
+		baseHi := uint64(3) // the odd primes below baseHi are in base
+
+		composite := make([]bool, sieveSegment)
+		var chunk []bool // the base primes from baseHi up are sieved here for every segment
+		for segLo := lo; ; {
+			// the segment holds the odd numbers segLo + 2i for i < count
+			count := min(uint64(sieveSegment), (hi-segLo+1)/2)
+			segLast := segLo + 2*(count-1)
+			sqrtLast := isqrt(segLast)
+			for baseHi <= sqrtLast && len(base) < sieveBaseLimit {
+				n := min(uint64(sieveSegment), (sqrtLast-baseHi)/2+1)
+				for i, c := range sieveOdd(composite[:n], baseHi, tiny) {
+					if !c {
+						p := baseHi + 2*uint64(i)
+						base = append(base, basePrime{uint32(p), uint32(oddOffset(NewModulus32(uint32(p)), segLo))})
+					}
+				}
+				baseHi += 2 * n
+			}
+
+			seg := composite[:count]
+			clear(seg)
+			for j := range base {
+				b := &base[j]
+				i, p := uint64(b.next), uint64(b.p)
+				for ; i < count; i += p {
+					seg[i] = true
+				}
+				// the next segment starts right after this one
+				b.next = uint32(i - count)
+			}
+			for chunkLo := baseHi; chunkLo <= sqrtLast; {
+				if chunk == nil {
+					chunk = make([]bool, sieveSegment)
+				}
+				n := min(uint64(sieveSegment), (sqrtLast-chunkLo)/2+1)
+				for k, c := range sieveOdd(chunk[:n], chunkLo, tiny) {
+					if !c {
+						p := chunkLo + 2*uint64(k)
+						for i := oddOffset(NewModulus32(uint32(p)), segLo); i < count; i += p {
+							seg[i] = true
+						}
+					}
+				}
+				chunkLo += 2 * n
+			}
+
+			for i, c := range seg {
+				if !c && !yield(segLo+2*uint64(i)) {
+					return
+				}
+			}
+			if hi-segLast <= 2 {
+				return
+			}
+			segLo = segLast + 2
+		}
+	}
+}
+
+// tinyPrimes returns the odd primes up to limit, at most 2^16.
+func tinyPrimes(limit uint64) []Modulus32 {
+	composite := make([]bool, limit+1)
+	var primes []Modulus32
+	for p := uint64(3); p <= limit; p += 2 {
+		if composite[p] {
+			continue
+		}
+		for q := p * p; q <= limit; q += 2 * p {
+			composite[q] = true
+		}
+		primes = append(primes, NewModulus32(uint32(p)))
+	}
+	return primes
+}
+
+// sieveOdd marks seg[i] for the odd numbers segLo + 2i that are multiples of a tiny prime
+// other than the prime itself, and returns seg.
+func sieveOdd(seg []bool, segLo uint64, tiny []Modulus32) []bool {
+	clear(seg)
+	count := uint64(len(seg))
+	for _, p := range tiny {
+		d := uint64(p.Modulus())
+		for i := oddOffset(p, segLo); i < count; i += d {
+			seg[i] = true
+		}
+	}
+	return seg
+}
+
+// oddOffset returns the index i of the first odd multiple segLo + 2i of the odd prime p
+// that is at least p^2, so that p itself is not marked.
+func oddOffset(p Modulus32, segLo uint64) uint64 {
+	d := uint64(p.Modulus())
+	if sq := d * d; sq >= segLo {
+		return (sq - segLo) / 2
+	}
+	r := uint64(p.Reduce64(segLo))
+	if r == 0 {
+		return 0
+	}
+	// segLo + d - r is the first multiple, odd multiples are 2d apart
+	off := d - r
+	if off&1 != 0 {
+		off += d
+	}
+	return off / 2
+}
+
+// isqrt calculates the integer square root floor(√n).
+func isqrt(n uint64) uint64 {
+	r := uint64(math.Sqrt(float64(n)))
+	// the float64 rounding is off by at most one in either direction
+	for r > math.MaxUint32 || r*r > n {
+		r--
+	}
+	for r < math.MaxUint32 && (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
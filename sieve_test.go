@@ -0,0 +1,110 @@
+package fastdiv
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestSieve(t *testing.T) {
+	const limit = 1 << 18 // several segments
+	prime := sieve(limit)
+	check := func(a, b uint32) bool {
+		lo, hi := uint64(a%limit), uint64(b%limit)
+		var want []uint64
+		for n := lo; n < hi; n++ {
+			if prime[n] {
+				want = append(want, n)
+			}
+		}
+		return slices.Equal(slices.Collect(Sieve(lo, hi)), want)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	for _, r := range [][2]uint64{{0, 0}, {0, 2}, {0, 3}, {2, 3}, {3, 3}, {4, 5}, {5, 4}, {0, limit}, {2*sieveSegment - 7, 2*sieveSegment + 20}} {
+		var want []uint64
+		for n := r[0]; n < r[1]; n++ {
+			if prime[n] {
+				want = append(want, n)
+			}
+		}
+		if got := slices.Collect(Sieve(r[0], r[1])); !slices.Equal(got, want) {
+			t.Errorf("Sieve(%d, %d): got %d primes, want %d", r[0], r[1], len(got), len(want))
+		}
+	}
+}
+
+func TestSieveLarge(t *testing.T) {
+	for _, lo := range []uint64{1<<32 - 100000, 1 << 40, 1<<48 + 12345} {
+		if testing.Short() && lo > 1<<40 {
+			continue
+		}
+		hi := lo + 3*2*sieveSegment + 101
+		var want []uint64
+		for n := lo; n < hi; n++ {
+			if IsPrime64(n) {
+				want = append(want, n)
+			}
+		}
+		if got := slices.Collect(Sieve(lo, hi)); !slices.Equal(got, want) {
+			t.Errorf("Sieve(%d, %d): got %d primes, want %d", lo, hi, len(got), len(want))
+		}
+	}
+}
+
+func TestSieveHuge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the base primes up to 2^30 in short mode")
+	}
+	// the base primes beyond sieveBaseLimit are sieved again for the window
+	lo := uint64(1<<60 + 12345)
+	hi := lo + 1000
+	var want []uint64
+	for n := lo; n < hi; n++ {
+		if IsPrime64(n) {
+			want = append(want, n)
+		}
+	}
+	if got := slices.Collect(Sieve(lo, hi)); !slices.Equal(got, want) {
+		t.Errorf("Sieve(%d, %d): got %v, want %v", lo, hi, got, want)
+	}
+}
+
+func TestSieveBreak(t *testing.T) {
+	var got []uint64
+	for p := range Sieve(1<<40, math.MaxUint64) {
+		got = append(got, p)
+		if len(got) == 3 {
+			break
+		}
+	}
+	// the three smallest primes above 2^40
+	if want := []uint64{1<<40 + 15, 1<<40 + 27, 1<<40 + 55}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	check := func(n uint64) bool {
+		r := isqrt(n)
+		return r*r <= n && (r == math.MaxUint32 || (r+1)*(r+1) > n)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+	for _, n := range []uint64{0, 1, 3, 4, 1<<52 - 1, 1 << 52, math.MaxUint32 * math.MaxUint32, math.MaxUint32*math.MaxUint32 - 1, math.MaxUint64} {
+		if !check(n) {
+			t.Errorf("isqrt(%d) = %d", n, isqrt(n))
+		}
+	}
+}
+
+func BenchmarkSieve(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for p := range Sieve(1<<40, 1<<40+1<<20) {
+			sinkUint64 = p
+		}
+	}
+}
@@ -0,0 +1,811 @@
+package fastdiv
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint8) DivSlice(dst, src []uint8) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		shiftSlice(dst, src, d.shift, false)
+		return
+	}
+	for i, n := range src {
+		div, _ := mul16(d.m, uint16(n))
+		dst[i] = uint8(div)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint8) ModSlice(dst, src []uint8) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		maskSlice(dst, src, d.shift)
+		return
+	}
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Uint8) DivModSlice(q, r, src []uint8) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	if d.pow2 {
+		shiftMaskSlice(q, r, src, d.shift, false)
+		return
+	}
+	for i, n := range src {
+		div, fraction := mul16(d.m, uint16(n))
+		mod, _ := mul16(fraction, d.d)
+		q[i], r[i] = uint8(div), uint8(mod)
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Uint8) DivisibleMask(dst []uint64, src []uint8) {
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Uint8) FilterDivisible(s []uint8) []uint8 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint16) DivSlice(dst, src []uint16) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		shiftSlice(dst, src, d.shift, false)
+		return
+	}
+	k := batchDivUint16(dst, src, d.m)
+	dst, src = dst[k:], src[k:]
+	for i, n := range src {
+		div, _ := bits.Mul32(d.m, uint32(n))
+		dst[i] = uint16(div)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint16) ModSlice(dst, src []uint16) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		maskSlice(dst, src, d.shift)
+		return
+	}
+	k := batchModUint16(dst, src, d.m, d.d)
+	dst, src = dst[k:], src[k:]
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Uint16) DivModSlice(q, r, src []uint16) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	if d.pow2 {
+		shiftMaskSlice(q, r, src, d.shift, false)
+		return
+	}
+	for i, n := range src {
+		div, fraction := bits.Mul32(d.m, uint32(n))
+		mod, _ := bits.Mul32(fraction, d.d)
+		q[i], r[i] = uint16(div), uint16(mod)
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Uint16) DivisibleMask(dst []uint64, src []uint16) {
+	dst = dst[:(len(src)+63)/64]
+	k := batchDivisibleUint16(dst, src, d.m)
+	dst, src = dst[k/64:], src[k:]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Uint16) FilterDivisible(s []uint16) []uint16 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint32) DivSlice(dst, src []uint32) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		shiftSlice(dst, src, d.shift, false)
+		return
+	}
+	k := batchDivUint32(dst, src, d.m)
+	dst, src = dst[k:], src[k:]
+	for i, n := range src {
+		div, _ := bits.Mul64(d.m, uint64(n))
+		dst[i] = uint32(div)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint32) ModSlice(dst, src []uint32) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		maskSlice(dst, src, d.shift)
+		return
+	}
+	k := batchModUint32(dst, src, d.m, d.d)
+	dst, src = dst[k:], src[k:]
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Uint32) DivModSlice(q, r, src []uint32) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	if d.pow2 {
+		shiftMaskSlice(q, r, src, d.shift, false)
+		return
+	}
+	for i, n := range src {
+		div, fraction := bits.Mul64(d.m, uint64(n))
+		mod, _ := bits.Mul64(fraction, d.d)
+		q[i], r[i] = uint32(div), uint32(mod)
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Uint32) DivisibleMask(dst []uint64, src []uint32) {
+	dst = dst[:(len(src)+63)/64]
+	k := batchDivisibleUint32(dst, src, d.m, d.d)
+	dst, src = dst[k/64:], src[k:]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Uint32) FilterDivisible(s []uint32) []uint32 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint64) DivSlice(dst, src []uint64) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		shiftSlice(dst, src, d.shift, false)
+		return
+	}
+	for i, n := range src {
+		divlo1, _ := bits.Mul64(d.lo, n)
+		div, divlo2 := bits.Mul64(d.hi, n)
+		_, c := bits.Add64(divlo1, divlo2, 0)
+		dst[i] = div + c
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint64) ModSlice(dst, src []uint64) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		maskSlice(dst, src, d.shift)
+		return
+	}
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Uint64) DivModSlice(q, r, src []uint64) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	if d.pow2 {
+		shiftMaskSlice(q, r, src, d.shift, false)
+		return
+	}
+	for i, n := range src {
+		divlo1, _ := bits.Mul64(d.lo, n)
+		div, divlo2 := bits.Mul64(d.hi, n)
+		_, c := bits.Add64(divlo1, divlo2, 0)
+		q[i], r[i] = div+c, n-(div+c)*d.d
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Uint64) DivisibleMask(dst []uint64, src []uint64) {
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Uint64) FilterDivisible(s []uint64) []uint64 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int8) DivSlice(dst, src []int8) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Div(n)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int8) ModSlice(dst, src []int8) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Int8) DivModSlice(q, r, src []int8) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	v := d.value()
+	for i, n := range src {
+		div := d.Div(n)
+		q[i], r[i] = div, n-div*v
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Int8) DivisibleMask(dst []uint64, src []int8) {
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Int8) FilterDivisible(s []int8) []int8 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int16) DivSlice(dst, src []int16) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Div(n)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int16) ModSlice(dst, src []int16) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Int16) DivModSlice(q, r, src []int16) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	v := d.value()
+	for i, n := range src {
+		div := d.Div(n)
+		q[i], r[i] = div, n-div*v
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Int16) DivisibleMask(dst []uint64, src []int16) {
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Int16) FilterDivisible(s []int16) []int16 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int32) DivSlice(dst, src []int32) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Div(n)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int32) ModSlice(dst, src []int32) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Int32) DivModSlice(q, r, src []int32) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	v := d.value()
+	for i, n := range src {
+		div := d.Div(n)
+		q[i], r[i] = div, n-div*v
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Int32) DivisibleMask(dst []uint64, src []int32) {
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Int32) FilterDivisible(s []int32) []int32 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int64) DivSlice(dst, src []int64) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		shiftSlice(dst, src, d.shift, d.neg)
+		return
+	}
+	var dsign uint64
+	if d.neg {
+		dsign = ^uint64(0)
+	}
+	for i, n := range src {
+		sign := uint64(n >> 63)
+		absn := uint64(n) ^ sign - sign
+		divlo1, _ := bits.Mul64(d.lo, absn)
+		div, divlo2 := bits.Mul64(d.hi, absn)
+		_, c := bits.Add64(divlo1, divlo2, 0)
+		sign ^= dsign
+		dst[i] = int64((div + c) ^ sign - sign)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Int64) ModSlice(dst, src []int64) {
+	dst = dst[:len(src)]
+	if d.pow2 {
+		maskSlice(dst, src, d.shift)
+		return
+	}
+	for i, n := range src {
+		sign := uint64(n >> 63)
+		absn := uint64(n) ^ sign - sign
+		hi, lo := bits.Mul64(d.lo, absn)
+		hi += d.hi * absn
+		modlo1, _ := bits.Mul64(lo, d.absd)
+		mod, modlo2 := bits.Mul64(hi, d.absd)
+		_, c := bits.Add64(modlo1, modlo2, 0)
+		dst[i] = int64((mod + c) ^ sign - sign)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Int64) DivModSlice(q, r, src []int64) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	if d.pow2 {
+		shiftMaskSlice(q, r, src, d.shift, d.neg)
+		return
+	}
+	var dsign uint64
+	if d.neg {
+		dsign = ^uint64(0)
+	}
+	v := d.value()
+	for i, n := range src {
+		sign := uint64(n >> 63)
+		absn := uint64(n) ^ sign - sign
+		divlo1, _ := bits.Mul64(d.lo, absn)
+		div, divlo2 := bits.Mul64(d.hi, absn)
+		_, c := bits.Add64(divlo1, divlo2, 0)
+		sign ^= dsign
+		div = (div + c) ^ sign - sign
+		q[i], r[i] = int64(div), n-int64(div)*v
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Int64) DivisibleMask(dst []uint64, src []int64) {
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Int64) FilterDivisible(s []int64) []int64 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the magic number multiplier.
+// It panics if len(dst) < len(src).
+func (d Uint64GMW) DivSlice(dst, src []uint64) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Div(n)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the magic number multiplier.
+// It panics if len(dst) < len(src).
+func (d Uint64GMW) ModSlice(dst, src []uint64) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the magic number multiplier. It panics if len(q) or len(r) < len(src).
+func (d Uint64GMW) DivModSlice(q, r, src []uint64) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	for i, n := range src {
+		q[i], r[i] = d.DivMod(n)
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Uint64GMW) DivisibleMask(dst []uint64, src []uint64) {
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Uint64GMW) FilterDivisible(s []uint64) []uint64 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the magic number multiplier.
+// It panics if len(dst) < len(src).
+func (d Int64GMW) DivSlice(dst, src []int64) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Div(n)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the magic number multiplier.
+// It panics if len(dst) < len(src).
+func (d Int64GMW) ModSlice(dst, src []int64) {
+	dst = dst[:len(src)]
+	for i, n := range src {
+		dst[i] = d.Mod(n)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the magic number multiplier. It panics if len(q) or len(r) < len(src).
+func (d Int64GMW) DivModSlice(q, r, src []int64) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	for i, n := range src {
+		q[i], r[i] = d.DivMod(n)
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Int64GMW) DivisibleMask(dst []uint64, src []int64) {
+	dst = dst[:(len(src)+63)/64]
+	for i := range dst {
+		var mask uint64
+		for j, n := range src[i*64 : min(i*64+64, len(src))] {
+			if d.Divisible(n) {
+				mask |= 1 << j
+			}
+		}
+		dst[i] = mask
+	}
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Int64GMW) FilterDivisible(s []int64) []int64 {
+	k := 0
+	for _, n := range s {
+		s[k] = n
+		if d.Divisible(n) {
+			k++
+		}
+	}
+	return s[:k]
+}
+
+// shiftSlice calculates dst[i] = src[i] / 2^shift, negated if neg is set, for every element of src.
+// It implements DivSlice for divisors whose absolute value is a power of two.
+func shiftSlice[T Integer](dst, src []T, shift uint8, neg bool) {
+	dst = dst[:len(src)]
+	mask := T(1)<<shift - 1
+	var sign T
+	if neg {
+		sign = ^T(0)
+	}
+	for i, n := range src {
+		q := (n + pow2Bias(n, mask)) >> shift
+		dst[i] = q ^ sign - sign
+	}
+}
+
+// maskSlice calculates dst[i] = src[i] % 2^shift for every element of src.
+// It implements ModSlice for divisors whose absolute value is a power of two.
+func maskSlice[T Integer](dst, src []T, shift uint8) {
+	dst = dst[:len(src)]
+	mask := T(1)<<shift - 1
+	for i, n := range src {
+		bias := pow2Bias(n, mask)
+		dst[i] = (n+bias)&mask - bias
+	}
+}
+
+// shiftMaskSlice combines shiftSlice and maskSlice in a single pass, so q or r may alias src.
+func shiftMaskSlice[T Integer](q, r, src []T, shift uint8, neg bool) {
+	q = q[:len(src)]
+	r = r[:len(src)]
+	mask := T(1)<<shift - 1
+	var sign T
+	if neg {
+		sign = ^T(0)
+	}
+	for i, n := range src {
+		bias := pow2Bias(n, mask)
+		q[i], r[i] = (n+bias)>>shift^sign-sign, (n+bias)&mask-bias
+	}
+}
+
+// pow2Bias returns mask for negative n and 0 otherwise. Adding it to n before
+// shifting right makes the quotient truncate toward zero like the / operator.
+func pow2Bias[T Integer](n, mask T) T {
+	if isSigned[T]() {
+		return n >> (8*unsafe.Sizeof(n) - 1) & mask
+	}
+	return 0
+}
+
+// castSlice reinterprets s as a slice of the integer type To, which must have the same size as From.
+func castSlice[To, From Integer](s []From) []To {
+	return unsafe.Slice((*To)(unsafe.Pointer(unsafe.SliceData(s))), len(s))
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the strategy of d.
+// It panics if len(dst) < len(src).
+func (d AutoUint64) DivSlice(dst, src []uint64) {
+	switch d.s {
+	case StrategyGMW:
+		d.gmw.DivSlice(dst, src)
+	case StrategyHardware:
+		dst = dst[:len(src)]
+		for i, n := range src {
+			dst[i] = n / d.d
+		}
+	default:
+		d.lemire.DivSlice(dst, src)
+	}
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the strategy of d.
+// It panics if len(dst) < len(src).
+func (d AutoUint64) ModSlice(dst, src []uint64) {
+	switch d.s {
+	case StrategyGMW:
+		d.gmw.ModSlice(dst, src)
+	case StrategyHardware:
+		dst = dst[:len(src)]
+		for i, n := range src {
+			dst[i] = n % d.d
+		}
+	default:
+		d.lemire.ModSlice(dst, src)
+	}
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the strategy of d. It panics if len(q) or len(r) < len(src).
+func (d AutoUint64) DivModSlice(q, r, src []uint64) {
+	switch d.s {
+	case StrategyGMW:
+		d.gmw.DivModSlice(q, r, src)
+	case StrategyHardware:
+		q = q[:len(src)]
+		r = r[:len(src)]
+		for i, n := range src {
+			q[i], r[i] = n/d.d, n%d.d
+		}
+	default:
+		d.lemire.DivModSlice(q, r, src)
+	}
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d AutoUint64) DivisibleMask(dst []uint64, src []uint64) {
+	if d.s == StrategyGMW {
+		d.gmw.DivisibleMask(dst, src)
+		return
+	}
+	d.lemire.DivisibleMask(dst, src)
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d AutoUint64) FilterDivisible(s []uint64) []uint64 {
+	if d.s == StrategyGMW {
+		return d.gmw.FilterDivisible(s)
+	}
+	return d.lemire.FilterDivisible(s)
+}
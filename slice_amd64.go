@@ -0,0 +1,108 @@
+//go:build amd64 && !purego
+
+package fastdiv
+
+// useAVX2 reports whether the batch operations on []uint16 and []uint32 use the AVX2 kernels.
+var useAVX2 = hasAVX2()
+
+func hasAVX2() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 7 {
+		return false
+	}
+	const osxsave, avx = 1 << 27, 1 << 28
+	_, _, ecx1, _ := cpuid(1, 0)
+	if ecx1&osxsave == 0 || ecx1&avx == 0 {
+		return false
+	}
+	// the OS must save the XMM and YMM registers.
+	if xcr0, _ := xgetbv(); xcr0&6 != 6 {
+		return false
+	}
+	const avx2 = 1 << 5
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&avx2 != 0
+}
+
+// The batch functions process a prefix of src with the AVX2 kernels and return its length.
+// The callers have already checked len(dst) and handle the rest of src.
+// The kernels need m != 0, so the callers of the div kernels handle d == 1 themselves.
+
+func batchDivUint32(dst, src []uint32, m uint64) int {
+	n := len(src) &^ 7
+	if !useAVX2 || n == 0 {
+		return 0
+	}
+	divUint32AVX2(&dst[0], &src[0], n, m)
+	return n
+}
+
+func batchModUint32(dst, src []uint32, m, d uint64) int {
+	n := len(src) &^ 7
+	if !useAVX2 || n == 0 {
+		return 0
+	}
+	modUint32AVX2(&dst[0], &src[0], n, m, d)
+	return n
+}
+
+func batchDivisibleUint32(dst []uint64, src []uint32, m, d uint64) int {
+	n := len(src) &^ 63
+	if !useAVX2 || n == 0 || d == 1 {
+		return 0
+	}
+	divisibleUint32AVX2(&dst[0], &src[0], n, m, d)
+	return n
+}
+
+func batchDivUint16(dst, src []uint16, m uint32) int {
+	n := len(src) &^ 7
+	if !useAVX2 || n == 0 {
+		return 0
+	}
+	divUint16AVX2(&dst[0], &src[0], n, m)
+	return n
+}
+
+func batchModUint16(dst, src []uint16, m, d uint32) int {
+	n := len(src) &^ 7
+	if !useAVX2 || n == 0 {
+		return 0
+	}
+	modUint16AVX2(&dst[0], &src[0], n, m, d)
+	return n
+}
+
+func batchDivisibleUint16(dst []uint64, src []uint16, m uint32) int {
+	n := len(src) &^ 63
+	if !useAVX2 || n == 0 {
+		return 0
+	}
+	divisibleUint16AVX2(&dst[0], &src[0], n, m)
+	return n
+}
+
+// The AVX2 kernels process n elements of src, n must be a positive multiple of 8,
+// or of 64 for the divisible kernels, which set one bit of dst per element.
+
+//go:noescape
+func divUint32AVX2(dst, src *uint32, n int, m uint64)
+
+//go:noescape
+func modUint32AVX2(dst, src *uint32, n int, m, d uint64)
+
+//go:noescape
+func divisibleUint32AVX2(dst *uint64, src *uint32, n int, m, d uint64)
+
+//go:noescape
+func divUint16AVX2(dst, src *uint16, n int, m uint32)
+
+//go:noescape
+func modUint16AVX2(dst, src *uint16, n int, m, d uint32)
+
+//go:noescape
+func divisibleUint16AVX2(dst *uint64, src *uint16, n int, m uint32)
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
@@ -0,0 +1,232 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// The uint32 kernels widen the 64-bit products of VPMULUDQ, which multiplies
+// the even 32-bit lanes, to the 96-bit product n * m by splitting m into
+// its 32-bit halves. The odd lanes are shifted down and blended back after.
+
+// func divUint32AVX2(dst, src *uint32, n int, m uint64)
+TEXT ·divUint32AVX2(SB), NOSPLIT, $0-32
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVQ m+24(FP), AX
+	MOVQ AX, X8
+	VPBROADCASTQ X8, Y8 // low half of m
+	SHRQ $32, AX
+	MOVQ AX, X9
+	VPBROADCASTQ X9, Y9 // high half of m
+	SHRQ $3, CX
+
+divUint32Loop:
+	VMOVDQU  (SI), Y0
+	VPSRLQ   $32, Y0, Y1
+	VPMULUDQ Y8, Y0, Y2
+	VPMULUDQ Y9, Y0, Y3
+	VPSRLQ   $32, Y2, Y2
+	VPADDQ   Y2, Y3, Y3
+	VPSRLQ   $32, Y3, Y3
+	VPMULUDQ Y8, Y1, Y4
+	VPMULUDQ Y9, Y1, Y5
+	VPSRLQ   $32, Y4, Y4
+	VPADDQ   Y4, Y5, Y5
+	VPBLENDD $0xaa, Y5, Y3, Y0
+	VMOVDQU  Y0, (DI)
+	ADDQ     $32, SI
+	ADDQ     $32, DI
+	DECQ     CX
+	JNZ      divUint32Loop
+	VZEROUPPER
+	RET
+
+// func modUint32AVX2(dst, src *uint32, n int, m, d uint64)
+TEXT ·modUint32AVX2(SB), NOSPLIT, $0-40
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVQ m+24(FP), AX
+	MOVQ AX, X8
+	VPBROADCASTQ X8, Y8 // low half of m
+	SHRQ $32, AX
+	MOVQ AX, X9
+	VPBROADCASTQ X9, Y9 // high half of m
+	VPBROADCASTQ d+32(FP), Y10
+	SHRQ $3, CX
+
+modUint32Loop:
+	VMOVDQU  (SI), Y0
+	VPSRLQ   $32, Y0, Y1
+	VPMULUDQ Y8, Y0, Y2  // low half of the fraction in the low lane
+	VPMULUDQ Y9, Y0, Y3
+	VPSRLQ   $32, Y2, Y4
+	VPADDQ   Y4, Y3, Y3  // high half of the fraction in the low lane
+	VPMULUDQ Y10, Y2, Y2
+	VPMULUDQ Y10, Y3, Y3
+	VPSRLQ   $32, Y2, Y2
+	VPADDQ   Y2, Y3, Y3
+	VPSRLQ   $32, Y3, Y3
+	VPMULUDQ Y8, Y1, Y4
+	VPMULUDQ Y9, Y1, Y5
+	VPSRLQ   $32, Y4, Y6
+	VPADDQ   Y6, Y5, Y5
+	VPMULUDQ Y10, Y4, Y4
+	VPMULUDQ Y10, Y5, Y5
+	VPSRLQ   $32, Y4, Y4
+	VPADDQ   Y4, Y5, Y5
+	VPBLENDD $0xaa, Y5, Y3, Y0
+	VMOVDQU  Y0, (DI)
+	ADDQ     $32, SI
+	ADDQ     $32, DI
+	DECQ     CX
+	JNZ      modUint32Loop
+	VZEROUPPER
+	RET
+
+// n is divisible by d when (n / d) * d == n, which avoids
+// comparing the 64-bit fraction without unsigned quadword compares.
+
+// func divisibleUint32AVX2(dst *uint64, src *uint32, n int, m, d uint64)
+TEXT ·divisibleUint32AVX2(SB), NOSPLIT, $0-40
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVQ m+24(FP), AX
+	MOVQ AX, X8
+	VPBROADCASTQ X8, Y8 // low half of m
+	SHRQ $32, AX
+	MOVQ AX, X9
+	VPBROADCASTQ X9, Y9 // high half of m
+	VPBROADCASTD d+32(FP), Y10
+	SHRQ $3, CX
+
+divisibleUint32Loop:
+	VMOVDQU   (SI), Y0
+	VPSRLQ    $32, Y0, Y1
+	VPMULUDQ  Y8, Y0, Y2
+	VPMULUDQ  Y9, Y0, Y3
+	VPSRLQ    $32, Y2, Y2
+	VPADDQ    Y2, Y3, Y3
+	VPSRLQ    $32, Y3, Y3
+	VPMULUDQ  Y8, Y1, Y4
+	VPMULUDQ  Y9, Y1, Y5
+	VPSRLQ    $32, Y4, Y4
+	VPADDQ    Y4, Y5, Y5
+	VPBLENDD  $0xaa, Y5, Y3, Y2
+	VPMULLD   Y10, Y2, Y2
+	VPCMPEQD  Y2, Y0, Y0
+	VMOVMSKPS Y0, AX
+	MOVB      AX, (DI)
+	ADDQ      $32, SI
+	ADDQ      $1, DI
+	DECQ      CX
+	JNZ       divisibleUint32Loop
+	VZEROUPPER
+	RET
+
+// The uint16 kernels zero-extend 8 elements to 32-bit lanes, so the low
+// half of the product n * m is a single VPMULLD.
+
+// func divUint16AVX2(dst, src *uint16, n int, m uint32)
+TEXT ·divUint16AVX2(SB), NOSPLIT, $0-28
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVL m+24(FP), AX
+	MOVL AX, X8
+	VPBROADCASTD X8, Y8
+	SHRQ $3, CX
+
+divUint16Loop:
+	VPMOVZXWD    (SI), Y0
+	VPSRLQ       $32, Y0, Y1
+	VPMULUDQ     Y8, Y0, Y2
+	VPSRLQ       $32, Y2, Y2
+	VPMULUDQ     Y8, Y1, Y3
+	VPBLENDD     $0xaa, Y3, Y2, Y0
+	VEXTRACTI128 $1, Y0, X1
+	VPACKUSDW    X1, X0, X0
+	VMOVDQU      X0, (DI)
+	ADDQ         $16, SI
+	ADDQ         $16, DI
+	DECQ         CX
+	JNZ          divUint16Loop
+	VZEROUPPER
+	RET
+
+// func modUint16AVX2(dst, src *uint16, n int, m, d uint32)
+TEXT ·modUint16AVX2(SB), NOSPLIT, $0-32
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVL m+24(FP), AX
+	MOVL AX, X8
+	VPBROADCASTD X8, Y8
+	MOVL d+28(FP), AX
+	MOVL AX, X9
+	VPBROADCASTD X9, Y9
+	SHRQ $3, CX
+
+modUint16Loop:
+	VPMOVZXWD    (SI), Y0
+	VPMULLD      Y8, Y0, Y0 // fraction
+	VPSRLQ       $32, Y0, Y1
+	VPMULUDQ     Y9, Y0, Y2
+	VPSRLQ       $32, Y2, Y2
+	VPMULUDQ     Y9, Y1, Y3
+	VPBLENDD     $0xaa, Y3, Y2, Y0
+	VEXTRACTI128 $1, Y0, X1
+	VPACKUSDW    X1, X0, X0
+	VMOVDQU      X0, (DI)
+	ADDQ         $16, SI
+	ADDQ         $16, DI
+	DECQ         CX
+	JNZ          modUint16Loop
+	VZEROUPPER
+	RET
+
+// func divisibleUint16AVX2(dst *uint64, src *uint16, n int, m uint32)
+TEXT ·divisibleUint16AVX2(SB), NOSPLIT, $0-28
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+	MOVQ n+16(FP), CX
+	MOVL m+24(FP), AX
+	MOVL AX, X8
+	VPBROADCASTD X8, Y8
+	DECL AX
+	MOVL AX, X9
+	VPBROADCASTD X9, Y9 // m-1
+	SHRQ $3, CX
+
+divisibleUint16Loop:
+	VPMOVZXWD (SI), Y0
+	VPMULLD   Y8, Y0, Y0 // fraction
+	VPMINUD   Y9, Y0, Y1
+	VPCMPEQD  Y1, Y0, Y0 // fraction <= m-1
+	VMOVMSKPS Y0, AX
+	MOVB      AX, (DI)
+	ADDQ      $16, SI
+	ADDQ      $1, DI
+	DECQ      CX
+	JNZ       divisibleUint16Loop
+	VZEROUPPER
+	RET
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
@@ -0,0 +1,109 @@
+//go:build amd64 && !purego
+
+package fastdiv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+// checkAVX2Uint32 compares the AVX2 kernels with the scalar methods of d on src.
+func checkAVX2Uint32(d Uint32, src []uint32) bool {
+	n := len(src) &^ 63
+	src = src[:n]
+	q, r := make([]uint32, n), make([]uint32, n)
+	mask := make([]uint64, n/64)
+	divUint32AVX2(&q[0], &src[0], n, d.m)
+	modUint32AVX2(&r[0], &src[0], n, d.m, d.d)
+	divisibleUint32AVX2(&mask[0], &src[0], n, d.m, d.d)
+	for i, x := range src {
+		// the callers handle d == 1 separately, the div and divisible kernels are never used for it.
+		if r[i] != d.Mod(x) || d.d != 1 && (q[i] != d.Div(x) ||
+			(mask[i/64]>>(i%64)&1 == 1) != d.Divisible(x)) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAVX2Uint16 compares the AVX2 kernels with the scalar methods of d on src.
+func checkAVX2Uint16(d Uint16, src []uint16) bool {
+	n := len(src) &^ 63
+	src = src[:n]
+	q, r := make([]uint16, n), make([]uint16, n)
+	mask := make([]uint64, n/64)
+	divUint16AVX2(&q[0], &src[0], n, d.m)
+	modUint16AVX2(&r[0], &src[0], n, d.m, d.d)
+	divisibleUint16AVX2(&mask[0], &src[0], n, d.m)
+	for i, x := range src {
+		if (d.d != 1 && q[i] != d.Div(x)) || r[i] != d.Mod(x) ||
+			(mask[i/64]>>(i%64)&1 == 1) != d.Divisible(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAVX2Uint32(t *testing.T) {
+	if !useAVX2 {
+		t.Skip("AVX2 is not available")
+	}
+	rng := rand.New(rand.NewSource(1))
+	edges := []uint32{0, 1, 2, 3, 7, 1<<16 - 1, 1 << 16, 1<<31 - 1, 1 << 31, 1<<31 + 1, math.MaxUint32 - 1, math.MaxUint32}
+	src := make([]uint32, 4096)
+	copy(src, edges)
+	for i := len(edges); i < len(src); i++ {
+		src[i] = rng.Uint32() >> rng.Intn(32)
+	}
+	for _, y := range edges[1:] {
+		if !checkAVX2Uint32(NewUint32(y), src) {
+			t.Errorf("AVX2 kernels do not match the scalar methods for d = %d", y)
+		}
+	}
+
+	checkRandom := func(y uint32) bool {
+		if y == 0 {
+			return true
+		}
+		return checkAVX2Uint32(NewUint32(y), src)
+	}
+	if err := quick.Check(checkRandom, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAVX2Uint16(t *testing.T) {
+	if !useAVX2 {
+		t.Skip("AVX2 is not available")
+	}
+	src := make([]uint16, 1<<16)
+	for i := range src {
+		src[i] = uint16(i)
+	}
+	step := 1
+	if testing.Short() {
+		step = 251
+	}
+	for y := 1; y < 1<<16; y += step {
+		if !checkAVX2Uint16(NewUint16(uint16(y)), src) {
+			t.Fatalf("AVX2 kernels do not match the scalar methods for d = %d", y)
+		}
+	}
+	if !checkAVX2Uint16(NewUint16(math.MaxUint16), src) {
+		t.Errorf("AVX2 kernels do not match the scalar methods for d = %d", math.MaxUint16)
+	}
+}
+
+func BenchmarkUint32DivSliceGeneric(b *testing.B) {
+	defer func(v bool) { useAVX2 = v }(useAVX2)
+	useAVX2 = false
+	BenchmarkUint32DivSlice(b)
+}
+
+func BenchmarkUint32DivisibleMaskGeneric(b *testing.B) {
+	defer func(v bool) { useAVX2 = v }(useAVX2)
+	useAVX2 = false
+	BenchmarkUint32DivisibleMask(b)
+}
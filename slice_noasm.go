@@ -0,0 +1,17 @@
+//go:build !amd64 || purego
+
+package fastdiv
+
+// Without the assembly kernels the batch operations run entirely in Go.
+
+func batchDivUint32(dst, src []uint32, m uint64) int { return 0 }
+
+func batchModUint32(dst, src []uint32, m, d uint64) int { return 0 }
+
+func batchDivisibleUint32(dst []uint64, src []uint32, m, d uint64) int { return 0 }
+
+func batchDivUint16(dst, src []uint16, m uint32) int { return 0 }
+
+func batchModUint16(dst, src []uint16, m, d uint32) int { return 0 }
+
+func batchDivisibleUint16(dst []uint64, src []uint16, m uint32) int { return 0 }
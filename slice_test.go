@@ -0,0 +1,209 @@
+package fastdiv
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"testing/quick"
+	"unsafe"
+)
+
+type sliceDivisor[T Integer] interface {
+	Div(n T) T
+	Mod(n T) T
+	Divisible(n T) bool
+	DivSlice(dst, src []T)
+	ModSlice(dst, src []T)
+	DivModSlice(q, r, src []T)
+	DivisibleMask(dst []uint64, src []T)
+	FilterDivisible(s []T) []T
+}
+
+// checkSlices compares every slice operation of d on src with the scalar methods.
+func checkSlices[T Integer, D sliceDivisor[T]](d D, src []T) bool {
+	n := len(src)
+	q, r := make([]T, n), make([]T, n)
+	q2, r2 := make([]T, n), make([]T, n)
+	d.DivSlice(q, src)
+	d.ModSlice(r, src)
+	d.DivModSlice(q2, r2, src)
+	mask := make([]uint64, (n+63)/64)
+	for i := range mask {
+		mask[i] = ^uint64(0)
+	}
+	d.DivisibleMask(mask, src)
+
+	var want []T
+	for i, x := range src {
+		if q[i] != d.Div(x) || r[i] != d.Mod(x) || q2[i] != q[i] || r2[i] != r[i] {
+			return false
+		}
+		if (mask[i/64]>>(i%64)&1 == 1) != d.Divisible(x) {
+			return false
+		}
+		if d.Divisible(x) {
+			want = append(want, x)
+		}
+	}
+	if n%64 != 0 && mask[len(mask)-1]>>(n%64) != 0 {
+		return false
+	}
+	if !slices.Equal(d.FilterDivisible(slices.Clone(src)), want) {
+		return false
+	}
+
+	// the operations must also work in place.
+	inPlace := slices.Clone(src)
+	d.DivSlice(inPlace, inPlace)
+	if !slices.Equal(inPlace, q) {
+		return false
+	}
+	inPlace = slices.Clone(src)
+	d.ModSlice(inPlace, inPlace)
+	if !slices.Equal(inPlace, r) {
+		return false
+	}
+	inPlace = slices.Clone(src)
+	d.DivModSlice(inPlace, r2, inPlace)
+	return slices.Equal(inPlace, q) && slices.Equal(r2, r)
+}
+
+func testSlices[T Integer, D sliceDivisor[T]](t *testing.T, newDivisor func(T) D) {
+	checkRandom := func(src []T, y T) bool {
+		if y == 0 {
+			return true
+		}
+		// repeat src to cover several words of the mask.
+		return checkSlices(newDivisor(y), slices.Concat(src, src, src, src))
+	}
+	if err := quick.Check(checkRandom, nil); err != nil {
+		t.Error(err)
+	}
+
+	top := T(1) << (8*unsafe.Sizeof(T(0)) - 1)
+	edges := []T{0, 1, 2, 3, top, top - 1, top + 1, ^T(0), -T(2), -T(3)}
+	src := slices.Clone(edges)
+	rng := rand.New(rand.NewSource(1))
+	for len(src) < 200 {
+		src = append(src, T(rng.Uint64()), T(rng.Intn(100)))
+	}
+	for _, y := range append(edges[1:], 6, 7, 64, 100) {
+		if !checkSlices(newDivisor(y), src) {
+			t.Errorf("slice operations do not match the scalar methods for d = %d", y)
+		}
+	}
+}
+
+func TestSlices(t *testing.T) {
+	t.Run("Uint8", func(t *testing.T) { testSlices(t, NewUint8) })
+	t.Run("Uint16", func(t *testing.T) { testSlices(t, NewUint16) })
+	t.Run("Uint32", func(t *testing.T) { testSlices(t, NewUint32) })
+	t.Run("Uint64", func(t *testing.T) { testSlices(t, NewUint64) })
+	t.Run("Int8", func(t *testing.T) { testSlices(t, NewInt8) })
+	t.Run("Int16", func(t *testing.T) { testSlices(t, NewInt16) })
+	t.Run("Int32", func(t *testing.T) { testSlices(t, NewInt32) })
+	t.Run("Int64", func(t *testing.T) { testSlices(t, NewInt64) })
+	t.Run("Uint64GMW", func(t *testing.T) { testSlices(t, NewUint64GMW) })
+	t.Run("Int64GMW", func(t *testing.T) { testSlices(t, NewInt64GMW) })
+	t.Run("AutoUint64", func(t *testing.T) {
+		testSlices(t, NewAutoUint64)
+		for _, s := range autoStrategies {
+			testSlices(t, func(y uint64) AutoUint64 { return newAutoUint64(y, s) })
+		}
+	})
+	t.Run("Uint", func(t *testing.T) { testSlices(t, NewUint) })
+	t.Run("Int", func(t *testing.T) { testSlices(t, NewInt) })
+	t.Run("Uintptr", func(t *testing.T) { testSlices(t, NewUintptr) })
+	t.Run("Divisor[shardID]", func(t *testing.T) { testSlices(t, New[shardID]) })
+	t.Run("Divisor[int16]", func(t *testing.T) { testSlices(t, New[int16]) })
+	t.Run("Divisor[int64]", func(t *testing.T) { testSlices(t, New[int64]) })
+	t.Run("Fallback[int32]", func(t *testing.T) {
+		testSlices(t, func(y int32) Fallback[int32] { return NewFallback(y, -1) })
+	})
+}
+
+func TestFallbackSlicesZero(t *testing.T) {
+	d := NewFallback(int32(0), -1)
+	src := []int32{3, 0, -5, 0}
+	q, r := make([]int32, len(src)), make([]int32, len(src))
+	d.DivModSlice(q, r, src)
+	for i := range src {
+		if q[i] != -1 || r[i] != -1 {
+			t.Errorf("%d / 0: got %d, %d, want fallback -1", src[i], q[i], r[i])
+		}
+	}
+	mask := []uint64{^uint64(0)}
+	d.DivisibleMask(mask, src)
+	if mask[0] != 0b1010 {
+		t.Errorf("DivisibleMask: got %b, want 1010", mask[0])
+	}
+	if got := d.FilterDivisible(src); !slices.Equal(got, []int32{0, 0}) {
+		t.Errorf("FilterDivisible: got %v, want [0 0]", got)
+	}
+}
+
+func TestSliceShortDst(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("DivSlice did not panic for a short dst")
+		}
+	}()
+	NewUint32(7).DivSlice(make([]uint32, 1), make([]uint32, 2))
+}
+
+var (
+	benchSliceUint32 = make([]uint32, 1024)
+	benchSliceInt64  = make([]int64, 1024)
+)
+
+func BenchmarkUint32DivSliceLoop(b *testing.B) {
+	d := NewUint32(varUint32)
+	dst := make([]uint32, len(benchSliceUint32))
+	for i := 0; i < b.N; i++ {
+		for j, n := range benchSliceUint32 {
+			dst[j] = d.Div(n)
+		}
+	}
+}
+
+func BenchmarkUint32DivSlice(b *testing.B) {
+	d := NewUint32(varUint32)
+	dst := make([]uint32, len(benchSliceUint32))
+	for i := 0; i < b.N; i++ {
+		d.DivSlice(dst, benchSliceUint32)
+	}
+}
+
+func BenchmarkUint32DivisibleMask(b *testing.B) {
+	d := NewUint32(varUint32)
+	dst := make([]uint64, len(benchSliceUint32)/64)
+	for i := 0; i < b.N; i++ {
+		d.DivisibleMask(dst, benchSliceUint32)
+	}
+}
+
+func BenchmarkInt64DivSliceLoop(b *testing.B) {
+	d := NewInt64(varInt64)
+	dst := make([]int64, len(benchSliceInt64))
+	for i := 0; i < b.N; i++ {
+		for j, n := range benchSliceInt64 {
+			dst[j] = d.Div(n)
+		}
+	}
+}
+
+func BenchmarkInt64DivSlice(b *testing.B) {
+	d := NewInt64(varInt64)
+	dst := make([]int64, len(benchSliceInt64))
+	for i := 0; i < b.N; i++ {
+		d.DivSlice(dst, benchSliceInt64)
+	}
+}
+
+func init() {
+	rng := rand.New(rand.NewSource(1))
+	for i := range benchSliceUint32 {
+		benchSliceUint32[i] = rng.Uint32()
+		benchSliceInt64[i] = int64(rng.Uint64())
+	}
+}
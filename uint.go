@@ -0,0 +1,78 @@
+package fastdiv
+
+// Uint calculates division by using a pre-computed inverse.
+// It uses the algorithm of Uint64 on 64-bit platforms and of Uint32 on 32-bit platforms.
+type Uint struct {
+	w uintWord
+}
+
+// NewUint initializes a new pre-computed inverse for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+func NewUint(d uint) Uint {
+	return Uint{w: newUintWord(uword(d))}
+}
+
+// TryNewUint initializes a new pre-computed inverse like NewUint,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewUint(d uint) (Uint, error) {
+	if d == 0 {
+		return Uint{}, ErrZeroDivisor
+	}
+	return NewUint(d), nil
+}
+
+// Div calculates n / d using the pre-computed inverse.
+func (d Uint) Div(n uint) uint {
+	return uint(d.w.Div(uword(n)))
+}
+
+// Mod calculates n % d using the pre-computed inverse.
+func (d Uint) Mod(n uint) uint {
+	return uint(d.w.Mod(uword(n)))
+}
+
+// DivMod calculates n / d and n % d using the pre-computed inverse.
+func (d Uint) DivMod(n uint) (q, r uint) {
+	div, mod := d.w.DivMod(uword(n))
+	return uint(div), uint(mod)
+}
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Uint) Divisible(n uint) bool {
+	return d.w.Divisible(uword(n))
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Uint) DivRound(n uint, mode RoundingMode) uint {
+	return uint(d.w.DivRound(uword(n), mode))
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint) DivSlice(dst, src []uint) {
+	d.w.DivSlice(castSlice[uword](dst), castSlice[uword](src))
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uint) ModSlice(dst, src []uint) {
+	d.w.ModSlice(castSlice[uword](dst), castSlice[uword](src))
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Uint) DivModSlice(q, r, src []uint) {
+	d.w.DivModSlice(castSlice[uword](q), castSlice[uword](r), castSlice[uword](src))
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Uint) DivisibleMask(dst []uint64, src []uint) {
+	d.w.DivisibleMask(dst, castSlice[uword](src))
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Uint) FilterDivisible(s []uint) []uint {
+	return s[:len(d.w.FilterDivisible(castSlice[uword](s)))]
+}
@@ -0,0 +1,86 @@
+package fastdiv
+
+import "math/bits"
+
+// Uint128By64 calculates division of a 128-bit dividend by a 64-bit divisor
+// by using a pre-computed reciprocal via the method of:
+//
+// "Improved division by invariant integers"
+// Niels Möller, Torbjörn Granlund
+// IEEE Transactions on Computers, 60(2), 2011
+//
+// The divisor is normalized so its top bit is set and the reciprocal is
+// floor((2^128 - 1) / d) - 2^64, which replaces the hardware division
+// instruction of bits.Div64 with two multiplications and a few corrections.
+type Uint128By64 struct {
+	d uint64 // normalized divisor d << s
+	v uint64 // reciprocal of the normalized divisor
+	s uint   // normalization shift
+}
+
+// NewUint128By64 initializes a new pre-computed reciprocal for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+func NewUint128By64(d uint64) Uint128By64 {
+	s := uint(bits.LeadingZeros64(d))
+	d <<= s
+	v, _ := bits.Div64(^d, ^uint64(0), d)
+	return Uint128By64{
+		d: d,
+		v: v,
+		s: s,
+	}
+}
+
+// TryNewUint128By64 initializes a new pre-computed reciprocal like NewUint128By64,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewUint128By64(d uint64) (Uint128By64, error) {
+	if d == 0 {
+		return Uint128By64{}, ErrZeroDivisor
+	}
+	return NewUint128By64(d), nil
+}
+
+// Div calculates (hi, lo) / d using the pre-computed reciprocal,
+// with the dividend bits' upper half in hi and the lower half in lo.
+// Like bits.Div64, Div panics for hi >= d (quotient overflow).
+func (d Uint128By64) Div(hi, lo uint64) uint64 {
+	quo, _ := d.DivMod(hi, lo)
+	return quo
+}
+
+// Mod calculates (hi, lo) % d using the pre-computed reciprocal,
+// with the dividend bits' upper half in hi and the lower half in lo.
+// Like bits.Rem64, Mod does not panic when the quotient overflows.
+func (d Uint128By64) Mod(hi, lo uint64) uint64 {
+	if hi >= d.d>>d.s {
+		_, hi = d.DivMod(0, hi)
+	}
+	_, rem := d.DivMod(hi, lo)
+	return rem
+}
+
+// DivMod calculates (hi, lo) / d and (hi, lo) % d using the pre-computed reciprocal,
+// with the dividend bits' upper half in hi and the lower half in lo.
+// Like bits.Div64, DivMod panics with the runtime overflow error for hi >= d (quotient overflow).
+func (d Uint128By64) DivMod(hi, lo uint64) (quo, rem uint64) {
+	if hi >= d.d>>d.s {
+		panic(errQuotientOverflow)
+	}
+	u1 := hi<<d.s | lo>>(64-d.s)
+	u0 := lo << d.s
+
+	// candidate quotient from the reciprocal, off by at most one
+	q1, q0 := bits.Mul64(d.v, u1)
+	q0, c := bits.Add64(q0, u0, 0)
+	q1, _ = bits.Add64(q1, u1+1, c)
+	r := u0 - q1*d.d
+	if r > q0 {
+		q1--
+		r += d.d
+	}
+	if r >= d.d {
+		q1++
+		r -= d.d
+	}
+	return q1, r >> d.s
+}
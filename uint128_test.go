@@ -0,0 +1,111 @@
+package fastdiv
+
+import (
+	"math"
+	"math/bits"
+	"runtime"
+	"testing"
+	"testing/quick"
+)
+
+var (
+	sinkUint128    uint64 = math.MaxUint64
+	varUint128By64 uint64 = 0x9e3779b97f4a7c15
+)
+
+func TestUint128By64DivMod(t *testing.T) {
+	checkUint128By64DivMod := func(hi, lo, y uint64) bool {
+		if y == 0 {
+			return true
+		}
+		hi %= y
+		d := NewUint128By64(y)
+		q, r := d.DivMod(hi, lo)
+		wantq, wantr := bits.Div64(hi, lo, y)
+		return q == wantq && r == wantr && d.Div(hi, lo) == wantq && d.Mod(hi, lo) == wantr
+	}
+
+	if err := quick.Check(checkUint128By64DivMod, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128By64Mod(t *testing.T) {
+	checkUint128By64Mod := func(hi, lo, y uint64) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewUint128By64(y)
+		return d.Mod(hi, lo) == bits.Rem64(hi, lo, y)
+	}
+
+	if err := quick.Check(checkUint128By64Mod, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128By64Edge(t *testing.T) {
+	edges := []uint64{0, 1, 2, 3, 7, 1 << 32, 1<<63 - 1, 1 << 63, 1<<63 + 1, math.MaxUint64 - 1, math.MaxUint64}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d := NewUint128By64(y)
+		for _, hi := range edges {
+			for _, lo := range edges {
+				if got, want := d.Mod(hi, lo), bits.Rem64(hi, lo, y); got != want {
+					t.Errorf("(%d, %d) %% %d: got %d, want %d", hi, lo, y, got, want)
+				}
+				if hi >= y {
+					continue
+				}
+				q, r := d.DivMod(hi, lo)
+				wantq, wantr := bits.Div64(hi, lo, y)
+				if q != wantq || r != wantr {
+					t.Errorf("(%d, %d) / %d: got %d, %d, want %d, %d", hi, lo, y, q, r, wantq, wantr)
+				}
+			}
+		}
+	}
+}
+
+func TestUint128By64Overflow(t *testing.T) {
+	var want any
+	func() {
+		defer func() { want = recover() }()
+		bits.Div64(7, 0, 7)
+	}()
+	defer func() {
+		got := recover()
+		if _, ok := got.(runtime.Error); !ok || got != want {
+			t.Errorf("DivMod(7, 0) by 7: got panic %v, want %v", got, want)
+		}
+	}()
+	NewUint128By64(7).DivMod(7, 0)
+}
+
+func BenchmarkUint128By64DivVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUint128, _ = bits.Div64(sinkUint128&math.MaxInt64, uint64(i), varUint128By64)
+	}
+}
+
+func BenchmarkUint128By64Div(b *testing.B) {
+	d := NewUint128By64(varUint128By64)
+	for i := 0; i < b.N; i++ {
+		sinkUint128 = d.Div(sinkUint128&math.MaxInt64, uint64(i))
+	}
+}
+
+func BenchmarkUint128By64ModVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUint128 = bits.Rem64(sinkUint128, uint64(i), varUint128By64)
+	}
+}
+
+func BenchmarkUint128By64Mod(b *testing.B) {
+	d := NewUint128By64(varUint128By64)
+	for i := 0; i < b.N; i++ {
+		sinkUint128 = d.Mod(sinkUint128, uint64(i))
+	}
+}
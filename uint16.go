@@ -4,36 +4,58 @@ import "math/bits"
 
 // Uint16 calculates division by using a pre-computed inverse.
 type Uint16 struct {
-	d uint32
-	m uint32
+	d     uint32
+	m     uint32
+	shift uint8 // log2(d) if pow2
+	pow2  bool
 }
 
 // NewUint16 initializes a new pre-computed inverse for d != 0.
 // If d == 0, a runtime divide-by-zero panic is raised.
+// Powers of two, including d == 1 where the inverse 2^32 wraps to m == 0,
+// divide with a shift and a mask instead.
 func NewUint16(d uint16) Uint16 {
 	return Uint16{
-		d: uint32(d),
-		m: ^uint32(0)/uint32(d) + 1,
+		d:     uint32(d),
+		m:     ^uint32(0)/uint32(d) + 1,
+		shift: uint8(bits.TrailingZeros16(d)),
+		pow2:  d&(d-1) == 0,
 	}
 }
 
+// TryNewUint16 initializes a new pre-computed inverse like NewUint16,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewUint16(d uint16) (Uint16, error) {
+	if d == 0 {
+		return Uint16{}, ErrZeroDivisor
+	}
+	return NewUint16(d), nil
+}
+
 // Div calculates n / d using the pre-computed inverse.
-// Note must have d > 1.
 func (d Uint16) Div(n uint16) uint16 {
+	if d.pow2 {
+		return n >> (d.shift & 15)
+	}
 	div, _ := bits.Mul32(d.m, uint32(n))
 	return uint16(div)
 }
 
 // Mod calculates n % d using the pre-computed inverse.
 func (d Uint16) Mod(n uint16) uint16 {
+	if d.pow2 {
+		return n & uint16(d.d-1)
+	}
 	fraction := d.m * uint32(n)
 	mod, _ := bits.Mul32(fraction, d.d)
 	return uint16(mod)
 }
 
 // DivMod calculates n / d and n % d using the pre-computed inverse.
-// Note must have d > 1.
 func (d Uint16) DivMod(n uint16) (uint16, uint16) {
+	if d.pow2 {
+		return n >> (d.shift & 15), n & uint16(d.d-1)
+	}
 	div, fraction := bits.Mul32(d.m, uint32(n))
 	mod, _ := bits.Mul32(fraction, d.d)
 	return uint16(div), uint16(mod)
@@ -15,7 +15,7 @@ const constUint16 uint16 = 12345
 
 func TestUint16Div(t *testing.T) {
 	checkUint16Div := func(x, y uint16) bool {
-		if y == 0 || y == 1 {
+		if y == 0 {
 			return true
 		}
 		d := NewUint16(y)
@@ -81,6 +81,31 @@ func TestUint16Divisible(t *testing.T) {
 	}
 }
 
+func TestUint16Exhaustive(t *testing.T) {
+	divisors := make([]uint16, 0, math.MaxUint16)
+	for y := 1; y <= math.MaxUint16; y++ {
+		if testing.Short() && y > 256 && y < math.MaxUint16-256 && y&(y-1) != 0 {
+			continue
+		}
+		divisors = append(divisors, uint16(y))
+	}
+
+	for _, y := range divisors {
+		d := NewUint16(y)
+		var q, r uint16
+		for x := 0; x <= math.MaxUint16; x++ {
+			n := uint16(x)
+			dq, dr := d.DivMod(n)
+			if d.Div(n) != q || d.Mod(n) != r || dq != q || dr != r || d.Divisible(n) != (r == 0) {
+				t.Fatalf("%d / %d: got %d, %d, want %d, %d", n, y, dq, dr, q, r)
+			}
+			if r++; r == y {
+				q, r = q+1, 0
+			}
+		}
+	}
+}
+
 func TestUint16DivSpeed(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping speed test in short mode")
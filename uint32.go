@@ -4,36 +4,58 @@ import "math/bits"
 
 // Uint32 calculates division by using a pre-computed inverse.
 type Uint32 struct {
-	d uint64
-	m uint64
+	d     uint64
+	m     uint64
+	shift uint8 // log2(d) if pow2
+	pow2  bool
 }
 
 // NewUint32 initializes a new pre-computed inverse for d != 0.
 // If d == 0, a runtime divide-by-zero panic is raised.
+// Powers of two, including d == 1 where the inverse 2^64 wraps to m == 0,
+// divide with a shift and a mask instead.
 func NewUint32(d uint32) Uint32 {
 	return Uint32{
-		d: uint64(d),
-		m: ^uint64(0)/uint64(d) + 1,
+		d:     uint64(d),
+		m:     ^uint64(0)/uint64(d) + 1,
+		shift: uint8(bits.TrailingZeros32(d)),
+		pow2:  d&(d-1) == 0,
 	}
 }
 
+// TryNewUint32 initializes a new pre-computed inverse like NewUint32,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewUint32(d uint32) (Uint32, error) {
+	if d == 0 {
+		return Uint32{}, ErrZeroDivisor
+	}
+	return NewUint32(d), nil
+}
+
 // Div calculates n / d using the pre-computed inverse.
-// Note must have d > 1.
 func (d Uint32) Div(n uint32) uint32 {
+	if d.pow2 {
+		return n >> (d.shift & 31)
+	}
 	div, _ := bits.Mul64(d.m, uint64(n))
 	return uint32(div)
 }
 
 // Mod calculates n % d using the pre-computed inverse.
 func (d Uint32) Mod(n uint32) uint32 {
+	if d.pow2 {
+		return n & uint32(d.d-1)
+	}
 	fraction := d.m * uint64(n)
 	mod, _ := bits.Mul64(fraction, d.d)
 	return uint32(mod)
 }
 
 // DivMod calculates n / d and n % d using the pre-computed inverse.
-// Note must have d > 1.
 func (d Uint32) DivMod(n uint32) (uint32, uint32) {
+	if d.pow2 {
+		return n >> (d.shift & 31), n & uint32(d.d-1)
+	}
 	div, fraction := bits.Mul64(d.m, uint64(n))
 	mod, _ := bits.Mul64(fraction, d.d)
 	return uint32(div), uint32(mod)
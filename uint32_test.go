@@ -15,7 +15,7 @@ const constUint32 uint32 = 123456789
 
 func TestUint32Div(t *testing.T) {
 	checkUint32Div := func(x, y uint32) bool {
-		if y == 0 || y == 1 {
+		if y == 0 {
 			return true
 		}
 		d := NewUint32(y)
@@ -81,6 +81,22 @@ func TestUint32Divisible(t *testing.T) {
 	}
 }
 
+func TestUint32Edge(t *testing.T) {
+	edges := []uint32{0, 1, 2, 3, 7, 1 << 16, math.MaxUint32 / 2, math.MaxUint32/2 + 1, math.MaxUint32 - 1, math.MaxUint32}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d := NewUint32(y)
+		for _, x := range edges {
+			q, r := d.DivMod(x)
+			if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, x/y, x%y)
+			}
+		}
+	}
+}
+
 func TestUint32DivSpeed(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping speed test in short mode")
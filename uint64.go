@@ -6,9 +6,14 @@ import "math/bits"
 type Uint64 struct {
 	d      uint64
 	hi, lo uint64
+	shift  uint8 // log2(d) if pow2
+	pow2   bool
 }
 
-// NewUint64 initializes a new pre-computed inverse.
+// NewUint64 initializes a new pre-computed inverse for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+// Powers of two, including d == 1 where the inverse 2^128 wraps to hi, lo == 0,
+// divide with a shift and a mask instead.
 func NewUint64(d uint64) Uint64 {
 	hi, r := ^uint64(0)/d, ^uint64(0)%d
 	lo, _ := bits.Div64(r, ^uint64(0), d)
@@ -16,14 +21,28 @@ func NewUint64(d uint64) Uint64 {
 	lo, c = bits.Add64(lo, 1, 0)
 	hi, _ = bits.Add64(hi, 0, c)
 	return Uint64{
-		d:  d,
-		hi: hi,
-		lo: lo,
+		d:     d,
+		hi:    hi,
+		lo:    lo,
+		shift: uint8(bits.TrailingZeros64(d)),
+		pow2:  d&(d-1) == 0,
 	}
 }
 
+// TryNewUint64 initializes a new pre-computed inverse like NewUint64,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewUint64(d uint64) (Uint64, error) {
+	if d == 0 {
+		return Uint64{}, ErrZeroDivisor
+	}
+	return NewUint64(d), nil
+}
+
 // Div calculates n / d using the pre-computed inverse.
 func (d Uint64) Div(n uint64) uint64 {
+	if d.pow2 {
+		return n >> (d.shift & 63)
+	}
 	divlo1, _ := bits.Mul64(d.lo, n)
 	div, divlo2 := bits.Mul64(d.hi, n)
 	var c uint64
@@ -34,6 +53,9 @@ func (d Uint64) Div(n uint64) uint64 {
 
 // Mod calculates n % d using the pre-computed inverse.
 func (d Uint64) Mod(n uint64) uint64 {
+	if d.pow2 {
+		return n & (d.d - 1)
+	}
 	hi, lo := bits.Mul64(d.lo, n)
 	hi += d.hi * n
 	modlo1, _ := bits.Mul64(lo, d.d)
@@ -45,8 +67,10 @@ func (d Uint64) Mod(n uint64) uint64 {
 }
 
 // DivMod calculates n / d and n % d using the pre-computed inverse.
-// Note must have d > 1.
 func (d Uint64) DivMod(n uint64) (q, r uint64) {
+	if d.pow2 {
+		return n >> (d.shift & 63), n & (d.d - 1)
+	}
 	divlo1, lo := bits.Mul64(d.lo, n)
 	div, divlo2 := bits.Mul64(d.hi, n)
 
@@ -15,7 +15,7 @@ const constUint64 uint64 = 123456789
 
 func TestUint64Div(t *testing.T) {
 	checkUint64Div := func(x, y uint64) bool {
-		if y == 0 || y == 1 {
+		if y == 0 {
 			return true
 		}
 		d := NewUint64(y)
@@ -80,6 +80,23 @@ func TestUint64Divisible(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestUint64Edge(t *testing.T) {
+	edges := []uint64{0, 1, 2, 3, 7, 1 << 32, math.MaxUint64 / 2, math.MaxUint64/2 + 1, math.MaxUint64 - 1, math.MaxUint64}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d := NewUint64(y)
+		for _, x := range edges {
+			q, r := d.DivMod(x)
+			if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, x/y, x%y)
+			}
+		}
+	}
+}
+
 func TestUint64DivSpeed(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping speed test in short mode")
@@ -0,0 +1,96 @@
+package fastdiv
+
+import "math/bits"
+
+// Uint64GMW calculates division by using the magic number multiplier of
+// Granlund, Montgomery and Warren, the method compilers use for constant divisors.
+// It needs a single 64-bit high-multiplication instead of the 128-bit inverse of Uint64
+// and produces the same results as Uint64.
+type Uint64GMW struct {
+	d        uint64
+	m        uint64 // magic multiplier, without its top bit if add is set
+	inv      uint64 // inverse of the odd part of d modulo 2^64
+	lim      uint64 // largest quotient of a uint64 by d
+	add      uint64 // add indicator, all ones for 65-bit multipliers
+	sh1, sh2 uint8
+	tz       uint8 // trailing zeros of d
+}
+
+// NewUint64GMW initializes a new magic number multiplier for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+// Powers of two, including 1, use m == 0 and shift by sh1 + sh2 == log2(d).
+func NewUint64GMW(d uint64) Uint64GMW {
+	inv, tz := oddInverse64(d)
+	u := Uint64GMW{d: d, inv: inv, lim: ^uint64(0) / d, tz: tz}
+
+	l := bits.Len64(d) - 1
+	if d&(d-1) == 0 {
+		u.add = ^uint64(0)
+		u.sh1 = uint8(min(l, 1))
+		u.sh2 = uint8(l) - u.sh1
+		return u
+	}
+	// m = floor(2^(64+l) / d), where 2^l < d < 2^(l+1)
+	m, rem := bits.Div64(1<<l, 0, d)
+	if d-rem < 1<<l {
+		// the multiplier m+1 fits in 64 bits with shift l
+		u.m = m + 1
+		u.sh2 = uint8(l)
+		return u
+	}
+	// otherwise use the 65-bit multiplier 2^64 + m with the shift l+1
+	m += m
+	if twice := rem + rem; twice >= d || twice < rem {
+		m++
+	}
+	u.m = m + 1
+	u.add = ^uint64(0)
+	u.sh1 = 1
+	u.sh2 = uint8(l)
+	return u
+}
+
+// TryNewUint64GMW initializes a new magic number multiplier like NewUint64GMW,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewUint64GMW(d uint64) (Uint64GMW, error) {
+	if d == 0 {
+		return Uint64GMW{}, ErrZeroDivisor
+	}
+	return NewUint64GMW(d), nil
+}
+
+// Div calculates n / d using the magic number multiplier.
+func (d Uint64GMW) Div(n uint64) uint64 {
+	q, _ := bits.Mul64(d.m, n)
+	return (q + (n-q)&d.add>>(d.sh1&63)) >> (d.sh2 & 63)
+}
+
+// Mod calculates n % d using the magic number multiplier.
+func (d Uint64GMW) Mod(n uint64) uint64 {
+	return n - d.Div(n)*d.d
+}
+
+// DivMod calculates n / d and n % d using the magic number multiplier.
+func (d Uint64GMW) DivMod(n uint64) (q, r uint64) {
+	q = d.Div(n)
+	return q, n - q*d.d
+}
+
+// Divisible determines whether n is exactly divisible by d using the inverse of its odd part.
+// For n = q * d the product n * inv is q shifted left by the trailing zeros of d,
+// so rotating it back gives q <= lim. For any other n the rotated product exceeds lim.
+func (d Uint64GMW) Divisible(n uint64) bool {
+	return bits.RotateLeft64(n*d.inv, -int(d.tz)) <= d.lim
+}
+
+// oddInverse64 returns the inverse modulo 2^64 of the odd part of d != 0 and the trailing zeros of d.
+func oddInverse64(d uint64) (inv uint64, tz uint8) {
+	tz = uint8(bits.TrailingZeros64(d))
+	odd := d >> tz
+	// Newton's iteration doubles the correct low bits of the inverse, from 5 bits to 80.
+	inv = odd*3 ^ 2
+	for i := 0; i < 4; i++ {
+		inv *= 2 - odd*inv
+	}
+	return inv, tz
+}
@@ -0,0 +1,73 @@
+package fastdiv
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+func TestUint64GMW(t *testing.T) {
+	checkUint64GMW := func(x, y uint64) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewUint64GMW(y)
+		q, r := d.DivMod(x)
+		return d.Div(x) == x/y && d.Mod(x) == x%y && q == x/y && r == x%y &&
+			d.Divisible(x) == (x%y == 0) && d.Divisible(x/y*y)
+	}
+	if err := quick.Check(checkUint64GMW, nil); err != nil {
+		t.Error(err)
+	}
+
+	// small divisors take the other branch of the multiplier selection more often.
+	checkSmall := func(x uint64, y uint16) bool {
+		return checkUint64GMW(x, uint64(y)) && checkUint64GMW(x>>(y%64), uint64(y))
+	}
+	if err := quick.Check(checkSmall, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint64GMWEdge(t *testing.T) {
+	edges := []uint64{0, 1, 2, 3, 5, 6, 7, 641, 1<<32 - 1, 1 << 32, 1<<32 + 1, math.MaxUint64 / 3, math.MaxUint64 - 1, math.MaxUint64}
+	divisors := edges[1:]
+	for s := 1; s < 64; s++ {
+		divisors = append(divisors, 1<<s-1, 1<<s, 1<<s+1)
+	}
+	for _, y := range divisors {
+		d, c := NewUint64GMW(y), NewUint64(y)
+		for _, x := range append(edges, y-1, y, y+1, 2*y, math.MaxUint64-y) {
+			q, r := d.DivMod(x)
+			cq, cr := c.DivMod(x)
+			if d.Div(x) != c.Div(x) || d.Mod(x) != c.Mod(x) || q != cq || r != cr || d.Divisible(x) != c.Divisible(x) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, cq, cr)
+			}
+		}
+	}
+}
+
+func BenchmarkUint64GMWDiv(b *testing.B) {
+	d := NewUint64GMW(varUint64)
+	for i := 0; i < b.N; i++ {
+		sinkUint64 = d.Div(sinkUint64)
+	}
+}
+
+func BenchmarkUint64GMWMod(b *testing.B) {
+	d := NewUint64GMW(varUint64)
+	for i := 0; i < b.N; i++ {
+		sinkUint64 = d.Mod(sinkUint64)
+	}
+}
+
+func BenchmarkUint64GMWDivisible(b *testing.B) {
+	d := NewUint64GMW(varUint64)
+	for i := 0; i < b.N; i++ {
+		if d.Divisible(uint64(i)) {
+			sinkUint64 = 2.0
+		} else {
+			sinkUint64 = 1.0
+		}
+	}
+}
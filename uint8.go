@@ -0,0 +1,75 @@
+package fastdiv
+
+import "math/bits"
+
+// Uint8 calculates division by using a pre-computed inverse.
+type Uint8 struct {
+	d     uint16
+	m     uint16
+	shift uint8 // log2(d) if pow2
+	pow2  bool
+}
+
+// NewUint8 initializes a new pre-computed inverse for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+// Powers of two, including d == 1 where the inverse 2^16 wraps to m == 0,
+// divide with a shift and a mask instead.
+func NewUint8(d uint8) Uint8 {
+	return Uint8{
+		d:     uint16(d),
+		m:     ^uint16(0)/uint16(d) + 1,
+		shift: uint8(bits.TrailingZeros8(d)),
+		pow2:  d&(d-1) == 0,
+	}
+}
+
+// TryNewUint8 initializes a new pre-computed inverse like NewUint8,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewUint8(d uint8) (Uint8, error) {
+	if d == 0 {
+		return Uint8{}, ErrZeroDivisor
+	}
+	return NewUint8(d), nil
+}
+
+// Div calculates n / d using the pre-computed inverse.
+func (d Uint8) Div(n uint8) uint8 {
+	if d.pow2 {
+		return n >> (d.shift & 7)
+	}
+	div, _ := mul16(d.m, uint16(n))
+	return uint8(div)
+}
+
+// Mod calculates n % d using the pre-computed inverse.
+func (d Uint8) Mod(n uint8) uint8 {
+	if d.pow2 {
+		return n & uint8(d.d-1)
+	}
+	fraction := d.m * uint16(n)
+	mod, _ := mul16(fraction, d.d)
+	return uint8(mod)
+}
+
+// DivMod calculates n / d and n % d using the pre-computed inverse.
+func (d Uint8) DivMod(n uint8) (uint8, uint8) {
+	if d.pow2 {
+		return n >> (d.shift & 7), n & uint8(d.d-1)
+	}
+	div, fraction := mul16(d.m, uint16(n))
+	mod, _ := mul16(fraction, d.d)
+	return uint8(div), uint8(mod)
+}
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Uint8) Divisible(n uint8) bool {
+	return d.m*uint16(n) <= d.m-1
+}
+
+// mul16 returns the 32-bit product of x and y: (hi, lo) = x * y
+// with the product bits' upper half returned in hi and the lower half returned in lo.
+// It is the 16-bit analogue of bits.Mul32.
+func mul16(x, y uint16) (hi, lo uint16) {
+	tmp := uint32(x) * uint32(y)
+	return uint16(tmp >> 16), uint16(tmp)
+}
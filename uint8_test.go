@@ -0,0 +1,163 @@
+package fastdiv
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	sinkUint8 uint8 = math.MaxUint8
+	varUint8  uint8 = 123
+)
+
+const constUint8 uint8 = 123
+
+func TestUint8Exhaustive(t *testing.T) {
+	for y := 1; y <= math.MaxUint8; y++ {
+		d := NewUint8(uint8(y))
+		for x := 0; x <= math.MaxUint8; x++ {
+			n, y := uint8(x), uint8(y)
+			q, r := d.DivMod(n)
+			if d.Div(n) != n/y || d.Mod(n) != n%y || q != n/y || r != n%y || d.Divisible(n) != (n%y == 0) {
+				t.Fatalf("%d / %d: got %d, %d, want %d, %d", n, y, q, r, n/y, n%y)
+			}
+		}
+	}
+}
+
+func TestUint8DivSpeed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping speed test in short mode")
+	}
+	const samples = 100
+	results := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < samples; j++ {
+				sinkUint8 = sinkUint8 / varUint8
+			}
+		}
+	})
+	baseline := float64(results.NsPerOp()) / float64(samples)
+	t.Logf("baseline: %2.2f ns/op", baseline)
+
+	benchmarkUint8Div := func(b *testing.B, n int) {
+		for i := 0; i < b.N; i++ {
+			d := NewUint8(varUint8)
+			for j := 0; j < n; j++ {
+				sinkUint8 = d.Div(sinkUint8)
+			}
+		}
+	}
+
+	sizes := []int{1, 2, 3, 5, 8, 10}
+	for _, s := range sizes {
+		results = testing.Benchmark(func(b *testing.B) {
+			benchmarkUint8Div(b, s)
+		})
+		nsPerOp := float64(results.NsPerOp()) / float64(s)
+		t.Logf(" %2d divs: %2.2f ns/op, faster: %v", s, nsPerOp, nsPerOp < baseline)
+	}
+}
+
+func TestUint8ModSpeed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping speed test in short mode")
+	}
+	const samples = 100
+	results := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < samples; j++ {
+				sinkUint8 = sinkUint8 % varUint8
+			}
+		}
+	})
+	baseline := float64(results.NsPerOp()) / float64(samples)
+	t.Logf("baseline: %2.2f ns/op", baseline)
+
+	benchmarkUint8Mod := func(b *testing.B, n int) {
+		for i := 0; i < b.N; i++ {
+			d := NewUint8(varUint8)
+			for j := 0; j < n; j++ {
+				sinkUint8 = d.Mod(sinkUint8)
+			}
+		}
+	}
+
+	sizes := []int{1, 2, 3, 5, 8, 10}
+	for _, s := range sizes {
+		results = testing.Benchmark(func(b *testing.B) {
+			benchmarkUint8Mod(b, s)
+		})
+		nsPerOp := float64(results.NsPerOp()) / float64(s)
+		t.Logf(" %2d mods: %2.2f ns/op, faster: %v", s, nsPerOp, nsPerOp < baseline)
+	}
+}
+
+func BenchmarkUint8DivVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUint8 = sinkUint8 / varUint8
+	}
+}
+
+func BenchmarkUint8DivConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUint8 = sinkUint8 / constUint8
+	}
+}
+
+func BenchmarkUint8Div(b *testing.B) {
+	d := NewUint8(varUint8)
+	for i := 0; i < b.N; i++ {
+		sinkUint8 = d.Div(sinkUint8)
+	}
+}
+
+func BenchmarkUint8ModVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUint8 = sinkUint8 % varUint8
+	}
+}
+
+func BenchmarkUint8ModConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUint8 = sinkUint8 % constUint8
+	}
+}
+
+func BenchmarkUint8Mod(b *testing.B) {
+	d := NewUint8(varUint8)
+	for i := 0; i < b.N; i++ {
+		sinkUint8 = d.Mod(sinkUint8)
+	}
+}
+
+func BenchmarkUint8DivisibleVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (uint8(i) % varUint8) == 0 {
+			sinkUint8 = 2.0
+		} else {
+			sinkUint8 = 1.0
+		}
+	}
+}
+
+func BenchmarkUint8DivisibleConst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if (uint8(i) % constUint8) == 0 {
+			sinkUint8 = 2.0
+		} else {
+			sinkUint8 = 1.0
+		}
+	}
+}
+
+func BenchmarkUint8Divisible(b *testing.B) {
+	d := NewUint8(varUint8)
+	for i := 0; i < b.N; i++ {
+		if d.Divisible(uint8(i)) {
+			sinkUint8 = 2.0
+		} else {
+			sinkUint8 = 1.0
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package fastdiv
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+var (
+	sinkUint uint = math.MaxUint
+	varUint  uint = 123456789
+)
+
+func TestUintDivMod(t *testing.T) {
+	checkUintDivMod := func(x, y uint) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewUint(y)
+		q, r := d.DivMod(x)
+		return (x/y) == d.Div(x) && (x%y) == d.Mod(x) && (x/y) == q && (x%y) == r &&
+			((x%y) == 0) == d.Divisible(x)
+	}
+
+	if err := quick.Check(checkUintDivMod, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUintEdge(t *testing.T) {
+	edges := []uint{0, 1, 2, 7, 1 << 31, 1<<32 - 1, math.MaxUint/2 + 1, math.MaxUint}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d := NewUint(y)
+		for _, x := range edges {
+			q, r := d.DivMod(x)
+			if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, x/y, x%y)
+			}
+		}
+	}
+}
+
+func BenchmarkUintDivVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUint = sinkUint / varUint
+	}
+}
+
+func BenchmarkUintDiv(b *testing.B) {
+	d := NewUint(varUint)
+	for i := 0; i < b.N; i++ {
+		sinkUint = d.Div(sinkUint)
+	}
+}
+
+func BenchmarkUintModVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUint = sinkUint % varUint
+	}
+}
+
+func BenchmarkUintMod(b *testing.B) {
+	d := NewUint(varUint)
+	for i := 0; i < b.N; i++ {
+		sinkUint = d.Mod(sinkUint)
+	}
+}
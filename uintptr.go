@@ -0,0 +1,78 @@
+package fastdiv
+
+// Uintptr calculates division by using a pre-computed inverse.
+// It uses the algorithm of Uint64 on 64-bit platforms and of Uint32 on 32-bit platforms.
+type Uintptr struct {
+	w uintWord
+}
+
+// NewUintptr initializes a new pre-computed inverse for d != 0.
+// If d == 0, a runtime divide-by-zero panic is raised.
+func NewUintptr(d uintptr) Uintptr {
+	return Uintptr{w: newUintWord(uword(d))}
+}
+
+// TryNewUintptr initializes a new pre-computed inverse like NewUintptr,
+// but returns ErrZeroDivisor instead of panicking if d == 0.
+func TryNewUintptr(d uintptr) (Uintptr, error) {
+	if d == 0 {
+		return Uintptr{}, ErrZeroDivisor
+	}
+	return NewUintptr(d), nil
+}
+
+// Div calculates n / d using the pre-computed inverse.
+func (d Uintptr) Div(n uintptr) uintptr {
+	return uintptr(d.w.Div(uword(n)))
+}
+
+// Mod calculates n % d using the pre-computed inverse.
+func (d Uintptr) Mod(n uintptr) uintptr {
+	return uintptr(d.w.Mod(uword(n)))
+}
+
+// DivMod calculates n / d and n % d using the pre-computed inverse.
+func (d Uintptr) DivMod(n uintptr) (q, r uintptr) {
+	div, mod := d.w.DivMod(uword(n))
+	return uintptr(div), uintptr(mod)
+}
+
+// Divisible determines whether n is exactly divisible by d using the pre-computed inverse.
+func (d Uintptr) Divisible(n uintptr) bool {
+	return d.w.Divisible(uword(n))
+}
+
+// DivRound calculates n / d rounded according to mode using the pre-computed inverse.
+func (d Uintptr) DivRound(n uintptr, mode RoundingMode) uintptr {
+	return uintptr(d.w.DivRound(uword(n), mode))
+}
+
+// DivSlice calculates dst[i] = src[i] / d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uintptr) DivSlice(dst, src []uintptr) {
+	d.w.DivSlice(castSlice[uword](dst), castSlice[uword](src))
+}
+
+// ModSlice calculates dst[i] = src[i] % d for every element of src using the pre-computed inverse.
+// It panics if len(dst) < len(src).
+func (d Uintptr) ModSlice(dst, src []uintptr) {
+	d.w.ModSlice(castSlice[uword](dst), castSlice[uword](src))
+}
+
+// DivModSlice calculates q[i] = src[i] / d and r[i] = src[i] % d for every element of src
+// using the pre-computed inverse. It panics if len(q) or len(r) < len(src).
+func (d Uintptr) DivModSlice(q, r, src []uintptr) {
+	d.w.DivModSlice(castSlice[uword](q), castSlice[uword](r), castSlice[uword](src))
+}
+
+// DivisibleMask sets bit i%64 of dst[i/64] if src[i] is exactly divisible by d and clears it otherwise.
+// The bits past len(src) in the last word are cleared. It panics if len(dst) < (len(src)+63)/64.
+func (d Uintptr) DivisibleMask(dst []uint64, src []uintptr) {
+	d.w.DivisibleMask(dst, castSlice[uword](src))
+}
+
+// FilterDivisible moves the elements of s that are exactly divisible by d to the front of s,
+// keeping their order, and returns the shortened slice. The remaining elements of s are unspecified.
+func (d Uintptr) FilterDivisible(s []uintptr) []uintptr {
+	return s[:len(d.w.FilterDivisible(castSlice[uword](s)))]
+}
@@ -0,0 +1,69 @@
+package fastdiv
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+var (
+	sinkUintptr uintptr = ^uintptr(0)
+	varUintptr  uintptr = 123456789
+)
+
+func TestUintptrDivMod(t *testing.T) {
+	checkUintptrDivMod := func(x, y uintptr) bool {
+		if y == 0 {
+			return true
+		}
+		d := NewUintptr(y)
+		q, r := d.DivMod(x)
+		return (x/y) == d.Div(x) && (x%y) == d.Mod(x) && (x/y) == q && (x%y) == r &&
+			((x%y) == 0) == d.Divisible(x)
+	}
+
+	if err := quick.Check(checkUintptrDivMod, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUintptrEdge(t *testing.T) {
+	edges := []uintptr{0, 1, 2, 7, 1 << 31, 1<<32 - 1, ^uintptr(0)/2 + 1, ^uintptr(0)}
+	for _, y := range edges {
+		if y == 0 {
+			continue
+		}
+		d := NewUintptr(y)
+		for _, x := range edges {
+			q, r := d.DivMod(x)
+			if d.Div(x) != x/y || d.Mod(x) != x%y || q != x/y || r != x%y || d.Divisible(x) != (x%y == 0) {
+				t.Errorf("%d / %d: got %d, %d, want %d, %d", x, y, q, r, x/y, x%y)
+			}
+		}
+	}
+}
+
+func BenchmarkUintptrDivVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUintptr = sinkUintptr / varUintptr
+	}
+}
+
+func BenchmarkUintptrDiv(b *testing.B) {
+	d := NewUintptr(varUintptr)
+	for i := 0; i < b.N; i++ {
+		sinkUintptr = d.Div(sinkUintptr)
+	}
+}
+
+func BenchmarkUintptrModVar(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkUintptr = sinkUintptr % varUintptr
+	}
+}
+
+func BenchmarkUintptrMod(b *testing.B) {
+	d := NewUintptr(varUintptr)
+	for i := 0; i < b.N; i++ {
+		sinkUintptr = d.Mod(sinkUintptr)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !(amd64 || arm64 || loong64 || mips64 || mips64le || ppc64 || ppc64le || riscv64 || s390x || wasm)
+
+package fastdiv
+
+import "math/bits"
+
+// On 32-bit platforms Uint, Int and Uintptr use the single-word arithmetic of Uint32 and Int32.
+type (
+	uintWord = Uint32
+	intWord  = Int32
+	uword    = uint32
+	word     = int32
+)
+
+// fail to compile if the build constraint does not match the platform word size.
+const _ uint = 32 - bits.UintSize
+
+func newUintWord(d uint32) uintWord {
+	return NewUint32(d)
+}
+
+func newIntWord(d int32) intWord {
+	return NewInt32(d)
+}
@@ -0,0 +1,24 @@
+//go:build amd64 || arm64 || loong64 || mips64 || mips64le || ppc64 || ppc64le || riscv64 || s390x || wasm
+
+package fastdiv
+
+import "math/bits"
+
+// On 64-bit platforms Uint, Int and Uintptr use the 64-bit extended arithmetic.
+type (
+	uintWord = Uint64
+	intWord  = Int64
+	uword    = uint64
+	word     = int64
+)
+
+// fail to compile if the build constraint does not match the platform word size.
+const _ uint = bits.UintSize - 64
+
+func newUintWord(d uint64) uintWord {
+	return NewUint64(d)
+}
+
+func newIntWord(d int64) intWord {
+	return NewInt64(d)
+}